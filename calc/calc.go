@@ -0,0 +1,133 @@
+// Package calc is a barbell calculator. It rounds a single weight to what can be
+// loaded with the gear and recommends the plates, without planning a whole program.
+package calc
+
+import (
+	"bytes"
+	_ "embed" // used for embeding templates
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+var (
+	// ErrMissingWeight is returned when a Calculator has neither a Weight nor a Max.
+	ErrMissingWeight = errors.New("missing weight: calc")
+	// ErrInvalidPercent is returned for a Percent that isn't more than zero.
+	ErrInvalidPercent = errors.New("invalid percent: calc")
+	//go:embed templates/result.go.html
+	resultTemplate string
+)
+
+// Calculator takes a target Weight, or a Percent of a Max, in the units of the Gear.
+// Weight is used when it is set, otherwise the target is the Percent of the Max.
+type Calculator struct {
+	Gear    gear.Gear   `json:"gear"`
+	Weight  gear.Weight `json:"weight,omitempty"`
+	Max     gear.Weight `json:"max,omitempty"`
+	Percent float64     `json:"percent,omitempty"`
+}
+
+// Result is the Target weight rounded by the Gear to Weight, with the Plates to load
+// on one side of the bar. Below and Above are the nearest weights that can be loaded
+// lighter and heavier than Weight, zero when there are none. Diagram is an inline SVG
+// of one side of the bar loaded with the Plates.
+type Result struct {
+	Target  gear.Weight   `json:"target"`
+	Weight  gear.Weight   `json:"weight"`
+	Unit    gear.Unit     `json:"unit"`
+	Plates  []gear.Weight `json:"plates,omitempty"`
+	Below   gear.Weight   `json:"below,omitempty"`
+	Above   gear.Weight   `json:"above,omitempty"`
+	Diagram template.HTML `json:"-"`
+}
+
+// Target returns the weight to calculate, from either the Weight or the Percent of the Max.
+func (c Calculator) Target() (gear.Weight, error) {
+	if c.Weight != 0 {
+		return c.Weight, nil
+	}
+	if c.Max == 0 {
+		return 0, ErrMissingWeight
+	}
+	if c.Percent <= 0 {
+		return 0, ErrInvalidPercent
+	}
+	return c.Max.Mul(c.Percent / 100), nil
+}
+
+// Calculate rounds the target weight with the Gear and returns the Result.
+func (c Calculator) Calculate() (Result, error) {
+	target, err := c.Target()
+	if err != nil {
+		return Result{}, err
+	}
+	g := c.Gear
+	rounded, err := g.Round(target)
+	if err != nil {
+		return Result{}, err
+	}
+	plates, err := g.Recommend(rounded)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{
+		Target:  target,
+		Weight:  rounded,
+		Unit:    g.Unit,
+		Plates:  plates,
+		Diagram: g.Diagram(plates),
+	}
+
+	if next, err := g.Next(rounded); err == nil {
+		r.Above = next.Weight
+	}
+	// the nearest weight below is the rounded weight, one thousandth of a unit
+	// lighter, rounded down.
+	g.Rounding = gear.Floor
+	if below, err := g.Round(rounded - 1); err == nil && below < rounded {
+		r.Below = below
+	}
+	return r, nil
+}
+
+// Plan implements a liftplan.Planner
+func (c Calculator) Plan(f liftplan.Format) ([]byte, error) {
+	r, err := c.Calculate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch f {
+	case liftplan.JSON:
+		return json.Marshal(r)
+	case liftplan.HTML:
+		var b bytes.Buffer
+		t, _ := template.New("result").Parse(resultTemplate)
+		t.Execute(&b, r)
+		return b.Bytes(), nil
+	default:
+		return nil, errors.New("liftplan format not implemented")
+	}
+}
+
+// Values conforms to the Valuer interface and is part of the LiftPlanner interface
+func (c Calculator) Values() (url.Values, error) {
+	vals, err := gear.ToValues(c.Gear)
+	if err != nil {
+		return vals, err
+	}
+	if c.Weight != 0 {
+		vals.Set(namespace+".weight", c.Weight.String())
+	}
+	if c.Max != 0 {
+		vals.Set(namespace+".max", c.Max.String())
+		vals.Set(namespace+".percent", fmt.Sprintf("%v", c.Percent))
+	}
+	return vals, nil
+}
@@ -0,0 +1,139 @@
+package calc
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+func weights(fs ...float64) []gear.Weight {
+	w := make([]gear.Weight, len(fs))
+	for i, f := range fs {
+		w[i] = gear.NewWeight(f)
+	}
+	return w
+}
+
+func weightsEqual(a, b []gear.Weight) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTarget(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		calc     Calculator
+		expected gear.Weight
+		err      error
+	}{
+		{Calculator{Weight: 200 * gear.Precision}, 200 * gear.Precision, nil},
+		{Calculator{Weight: 200 * gear.Precision, Max: 300 * gear.Precision, Percent: 50}, 200 * gear.Precision, nil},
+		{Calculator{Max: 300 * gear.Precision, Percent: 65}, 195 * gear.Precision, nil},
+		{Calculator{Max: 300 * gear.Precision}, 0, ErrInvalidPercent},
+		{Calculator{Percent: 65}, 0, ErrMissingWeight},
+		{Calculator{}, 0, ErrMissingWeight},
+	}
+	for i, test := range tt {
+		w, err := test.calc.Target()
+		if w != test.expected || err != test.err {
+			t.Error("unexpected target:", i, w, err)
+		}
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+	g := gear.Default(gear.LBS)
+	limited := gear.Default(gear.LBS)
+	limited.Plates = gear.Plates{Weights: weights(45), Unit: gear.LBS}
+	limited.Plates.SetCount(45*gear.Precision, 2)
+	nearest := gear.Default(gear.LBS)
+	nearest.Rounding = gear.Nearest
+
+	tt := []struct {
+		calc     Calculator
+		expected Result
+		err      error
+	}{
+		{Calculator{Gear: g, Weight: 200 * gear.Precision}, Result{
+			Target: 200 * gear.Precision, Weight: 200 * gear.Precision, Unit: gear.LBS,
+			Plates: weights(2.5, 5, 25, 45), Below: 195 * gear.Precision, Above: 205 * gear.Precision,
+		}, nil},
+		{Calculator{Gear: g, Weight: 203 * gear.Precision}, Result{
+			Target: 203 * gear.Precision, Weight: 200 * gear.Precision, Unit: gear.LBS,
+			Plates: weights(2.5, 5, 25, 45), Below: 195 * gear.Precision, Above: 205 * gear.Precision,
+		}, nil},
+		{Calculator{Gear: nearest, Max: 300 * gear.Precision, Percent: 67}, Result{
+			Target: 201 * gear.Precision, Weight: 200 * gear.Precision, Unit: gear.LBS,
+			Plates: weights(2.5, 5, 25, 45), Below: 195 * gear.Precision, Above: 205 * gear.Precision,
+		}, nil},
+		{Calculator{Gear: g, Weight: 45 * gear.Precision}, Result{
+			Target: 45 * gear.Precision, Weight: 45 * gear.Precision, Unit: gear.LBS,
+			Above: 50 * gear.Precision,
+		}, nil},
+		{Calculator{Gear: limited, Weight: 135 * gear.Precision}, Result{
+			Target: 135 * gear.Precision, Weight: 135 * gear.Precision, Unit: gear.LBS,
+			Plates: weights(45), Below: 45 * gear.Precision,
+		}, nil},
+		{Calculator{Gear: g, Weight: 20 * gear.Precision}, Result{}, gear.ErrInputLessThanBar},
+		{Calculator{Gear: g}, Result{}, ErrMissingWeight},
+	}
+	for i, test := range tt {
+		r, err := test.calc.Calculate()
+		e := test.expected
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if r.Target != e.Target || r.Weight != e.Weight || r.Unit != e.Unit ||
+			!weightsEqual(r.Plates, e.Plates) || r.Below != e.Below || r.Above != e.Above {
+			t.Error("unexpected result:", i, r, e)
+		}
+		if err == nil && r.Diagram == "" {
+			t.Error("expected a diagram:", i)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	c := Calculator{Gear: gear.Default(gear.LBS), Weight: 200 * gear.Precision}
+
+	b, err := c.Plan(liftplan.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Result
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Error(err)
+	}
+	if r.Weight != 200*gear.Precision || r.Below != 195*gear.Precision || r.Above != 205*gear.Precision {
+		t.Error("unexpected json result:", string(b))
+	}
+
+	b, err = c.Plan(liftplan.HTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`<svg class="diagram"`)) {
+		t.Error("expected a bar diagram in the result:", string(b))
+	}
+
+	if _, err := c.Plan(5); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	c.Weight = 0
+	if _, err := c.Plan(liftplan.JSON); err != ErrMissingWeight {
+		t.Error("unexpected error:", err)
+	}
+}
@@ -0,0 +1,46 @@
+package calc
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+const (
+	namespace = "calc"
+)
+
+// FromValues takes a `url.Values` and builds and returns a Calculator and an error.
+// Empty values are ignored, so a form can leave either the weight or the max blank.
+func FromValues(v url.Values) (c Calculator, err error) {
+	g, err := gear.FromValues(v)
+	if err != nil {
+		return c, err
+	}
+	c.Gear = g
+
+	if w, ok := v[namespace+".weight"]; ok && w[0] != "" {
+		c.Weight, err = gear.ParseWeight(w[0])
+		if err != nil {
+			return c, fmt.Errorf("unable to convert %v to weight", w[0])
+		}
+	}
+	if m, ok := v[namespace+".max"]; ok && m[0] != "" {
+		c.Max, err = gear.ParseWeight(m[0])
+		if err != nil {
+			return c, fmt.Errorf("unable to convert %v to weight", m[0])
+		}
+	}
+	if p, ok := v[namespace+".percent"]; ok && p[0] != "" {
+		c.Percent, err = strconv.ParseFloat(p[0], 64)
+		if err != nil {
+			return c, fmt.Errorf("unable to convert %v to percent", p[0])
+		}
+	}
+	if _, err := c.Target(); err != nil {
+		return c, err
+	}
+	return c, nil
+}
@@ -0,0 +1,83 @@
+package calc
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestFromValues(t *testing.T) {
+	t.Parallel()
+	g := gear.Default(gear.LBS)
+	vals, err := gear.ToValues(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	with := func(kv ...string) url.Values {
+		v := url.Values{}
+		for k, s := range vals {
+			v[k] = s
+		}
+		for i := 0; i < len(kv); i += 2 {
+			v.Set(kv[i], kv[i+1])
+		}
+		return v
+	}
+
+	tt := []struct {
+		vals     url.Values
+		expected Calculator
+		err      bool
+	}{
+		{with("calc.weight", "202.5"), Calculator{Gear: g, Weight: gear.NewWeight(202.5)}, false},
+		{with("calc.weight", "", "calc.max", "300", "calc.percent", "65"), Calculator{Gear: g, Max: 300 * gear.Precision, Percent: 65}, false},
+		{with("calc.weight", "heavy"), Calculator{}, true},
+		{with("calc.max", "heavy", "calc.percent", "65"), Calculator{}, true},
+		{with("calc.max", "300", "calc.percent", "most"), Calculator{}, true},
+		{with("calc.max", "300"), Calculator{}, true},
+		{with(), Calculator{}, true},
+		{url.Values{"calc.weight": []string{"200"}}, Calculator{}, true},
+	}
+	for i, test := range tt {
+		c, err := FromValues(test.vals)
+		if (err != nil) != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		e := test.expected
+		if !c.Gear.Equals(e.Gear) || c.Weight != e.Weight || c.Max != e.Max || c.Percent != e.Percent {
+			t.Error("unexpected calculator:", i, c, e)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	tt := []Calculator{
+		{Gear: gear.Default(gear.LBS), Weight: gear.NewWeight(202.5)},
+		{Gear: gear.Default(gear.KG), Max: 180 * gear.Precision, Percent: 72.5},
+	}
+	for i, c := range tt {
+		v, err := c.Values()
+		if err != nil {
+			t.Error(i, err)
+			continue
+		}
+		r, err := FromValues(v)
+		if err != nil {
+			t.Error(i, err)
+			continue
+		}
+		if !r.Gear.Equals(c.Gear) || r.Weight != c.Weight || r.Max != c.Max || r.Percent != c.Percent {
+			t.Error("unexpected round trip:", i, r, c)
+		}
+	}
+	bad := Calculator{Gear: gear.Gear{Unit: 5}, Weight: 100 * gear.Precision}
+	if _, err := bad.Values(); err == nil {
+		t.Error("expected an error for invalid gear")
+	}
+}
@@ -0,0 +1,55 @@
+package gear
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidWeightAttachment is returned for an Attachment without any weight.
+	ErrInvalidWeightAttachment = errors.New("invalid weight: attachment")
+	ErrInvalidUnitAttachment   = errors.New("invalid unit: attachment")
+)
+
+var (
+	// CompetitionCollarsKG represents a pair of 2.5 KG competition collars.
+	CompetitionCollarsKG = Attachment{
+		Name:   "competition collars",
+		Weight: 5 * Precision,
+		Unit:   KG,
+	}
+)
+
+// Attachment is anything added to the bar that changes the load being lifted,
+// such as collars, chains, or bands. Weight is the total change in load for the
+// whole bar, so a pair of collars is a single Attachment. A negative Weight
+// takes load away, such as bands hung from above the bar.
+type Attachment struct {
+	Name   string `json:"name,omitempty"`
+	Weight Weight `json:"weight"`
+	Unit   Unit   `json:"unit"`
+}
+
+// ConvertTo takes a Unit and returns the converted weight or an error.
+func (a Attachment) ConvertTo(u Unit) (Weight, error) {
+	return ConvertFromTo(a.Weight, a.Unit, u)
+}
+
+// String prints the human readable string format of the attachment.
+func (a Attachment) String() string {
+	if a.Name == "" {
+		return fmt.Sprintf("Weight: %v, Unit: %v", a.Weight, a.Unit)
+	}
+	return fmt.Sprintf("Name: %v, Weight: %v, Unit: %v", a.Name, a.Weight, a.Unit)
+}
+
+// Valid checks that Unit is valid and that Weight != zero.
+func (a Attachment) Valid() error {
+	if !a.Unit.Valid() {
+		return ErrInvalidUnitAttachment
+	}
+	if a.Weight == 0 {
+		return ErrInvalidWeightAttachment
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gear
+
+import "testing"
+
+func TestAttachment(t *testing.T) {
+	t.Parallel()
+	bands := Attachment{Weight: -20 * Precision, Unit: LBS}
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Attachment
+			expected string
+		}{
+			{CompetitionCollarsKG, "Name: competition collars, Weight: 5, Unit: KG"},
+			{bands, "Weight: -20, Unit: LBS"},
+		}
+		for _, test := range tt {
+			if test.input.String() != test.expected {
+				t.Error("match failed for", test)
+			}
+		}
+	})
+	t.Run("ConvertTo", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Attachment
+			unit     Unit
+			expected float64
+			err      error
+		}{
+			{CompetitionCollarsKG, KG, 5, nil},
+			{CompetitionCollarsKG, LBS, 11.023, nil},
+			{bands, LBS, -20, nil},
+			{bands, Unit(5), 0, ErrInvalidUnit},
+		}
+		for _, test := range tt {
+			o, err := test.input.ConvertTo(test.unit)
+			if err != test.err {
+				t.Error("expected error mismatch:", err, test.err)
+			}
+			if o != NewWeight(test.expected) {
+				t.Error("match failed for", o, test.expected)
+			}
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input Attachment
+			err   error
+		}{
+			{CompetitionCollarsKG, nil},
+			{bands, nil},
+			{Attachment{Unit: KG}, ErrInvalidWeightAttachment},
+			{Attachment{Weight: 5 * Precision, Unit: Unit(5)}, ErrInvalidUnitAttachment},
+		}
+		for _, test := range tt {
+			if err := test.input.Valid(); err != test.err {
+				t.Error("unexpected error:", test.input, err, test.err)
+			}
+		}
+	})
+}
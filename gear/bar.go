@@ -8,51 +8,158 @@ import (
 var (
 	ErrInvalidWeightBar = errors.New("invalid weight: bar")
 	ErrInvalidUnitBar   = errors.New("invalid unit: bar")
+	// ErrInvalidSleeveBar is returned for a negative Sleeve length, or one longer
+	// than MaxSleeve.
+	ErrInvalidSleeveBar = errors.New("invalid sleeve: bar")
+	// ErrBarNotFound is returned when a name is not in the Bars catalog.
+	ErrBarNotFound = errors.New("bar not found")
 )
 
+// MaxSleeve is the longest Sleeve of a Bar in millimeters, well past the sleeve of
+// any real bar.
+const MaxSleeve = 1000.0
+
 var (
 	// MensBarKG represents a standard men's 20 KG barbell
 	MensBarKG = Bar{
-		Weight: 20.0,
+		Name:   "mens-kg",
+		Weight: 20 * Precision,
 		Unit:   KG,
+		Sleeve: 415,
 	}
 	// MensBarLBS represents a standard men's 45 LBS barbell
 	MensBarLBS = Bar{
-		Weight: 45.0,
+		Name:   "mens-lbs",
+		Weight: 45 * Precision,
 		Unit:   LBS,
+		Sleeve: 415,
 	}
 	// WomensBarKG represents a standard women's 15 KG barbell
 	WomensBarKG = Bar{
-		Weight: 15.0,
+		Name:   "womens-kg",
+		Weight: 15 * Precision,
 		Unit:   KG,
+		Sleeve: 320,
 	}
 	// WomensBarLBS represents a standard women's 35 LBS barbell
 	WomensBarLBS = Bar{
-		Weight: 35.0,
+		Name:   "womens-lbs",
+		Weight: 35 * Precision,
+		Unit:   LBS,
+		Sleeve: 320,
+	}
+	// TrapBar represents a 60 LBS trap, or hex, bar
+	TrapBar = Bar{
+		Name:   "trap",
+		Weight: 60 * Precision,
+		Unit:   LBS,
+		Sleeve: 250,
+	}
+	// SafetySquatBar represents a 65 LBS safety squat bar
+	SafetySquatBar = Bar{
+		Name:   "safety-squat",
+		Weight: 65 * Precision,
+		Unit:   LBS,
+		Sleeve: 400,
+	}
+	// EZCurlBar represents a 25 LBS EZ curl bar
+	EZCurlBar = Bar{
+		Name:   "ez-curl",
+		Weight: 25 * Precision,
+		Unit:   LBS,
+		Sleeve: 200,
+	}
+	// TechniqueBar represents a 15 LBS aluminum technique bar
+	TechniqueBar = Bar{
+		Name:   "technique",
+		Weight: 15 * Precision,
+		Unit:   LBS,
+		Sleeve: 300,
+	}
+	// TrainingBar represents a 25 LBS training bar
+	TrainingBar = Bar{
+		Name:   "training",
+		Weight: 25 * Precision,
+		Unit:   LBS,
+		Sleeve: 250,
+	}
+	// DumbbellHandleLBS represents a 5 LBS loadable dumbbell handle
+	DumbbellHandleLBS = Bar{
+		Name:   "dumbbell-handle-lbs",
+		Weight: 5 * Precision,
 		Unit:   LBS,
+		Sleeve: 125,
+	}
+	// DumbbellHandleKG represents a 2 KG loadable dumbbell handle
+	DumbbellHandleKG = Bar{
+		Name:   "dumbbell-handle-kg",
+		Weight: 2 * Precision,
+		Unit:   KG,
+		Sleeve: 125,
 	}
 )
 
-// Bar contains the weight and units of a Barbell
+// Bars is the catalog of named bars, see BarFromName.
+var Bars = []Bar{
+	MensBarLBS,
+	WomensBarLBS,
+	MensBarKG,
+	WomensBarKG,
+	TrapBar,
+	SafetySquatBar,
+	EZCurlBar,
+	TechniqueBar,
+	TrainingBar,
+	DumbbellHandleLBS,
+	DumbbellHandleKG,
+}
+
+// Bar contains the weight and units of a Barbell. Name is optional and used to find
+// the bar in the Bars catalog. Sleeve is the loadable length of one sleeve in
+// millimeters, zero when unknown. A Fixed bar can't be loaded with plates.
 type Bar struct {
-	Weight float64 `json:"weight"`
+	Name   string  `json:"name,omitempty"`
+	Weight Weight  `json:"weight"`
 	Unit   Unit    `json:"unit"`
+	Sleeve float64 `json:"sleeve,omitempty"`
+	Fixed  bool    `json:"fixed,omitempty"`
+}
+
+// BarFromName takes the Name of a bar and returns the bar from the Bars catalog
+// or ErrBarNotFound.
+func BarFromName(name string) (Bar, error) {
+	for _, b := range Bars {
+		if b.Name == name {
+			return b, nil
+		}
+	}
+	return Bar{}, ErrBarNotFound
 }
 
 // ConvertTo takes a Unit and returns the converted weight or an error.
 // If the bar is already in requested unit, it simply returns the weight.
-func (b Bar) ConvertTo(u Unit) (float64, error) {
+func (b Bar) ConvertTo(u Unit) (Weight, error) {
 	return ConvertFromTo(b.Weight, b.Unit, u)
 }
 
 // String prints the human readable string format of the bar data structure
 func (b Bar) String() string {
-	return fmt.Sprintf("Weight: %v, Unit: %v", b.Weight, b.Unit)
+	s := fmt.Sprintf("Weight: %v, Unit: %v", b.Weight, b.Unit)
+	if b.Name != "" {
+		s = fmt.Sprintf("Name: %v, ", b.Name) + s
+	}
+	if b.Sleeve != 0 {
+		s += fmt.Sprintf(", Sleeve: %vmm", b.Sleeve)
+	}
+	if b.Fixed {
+		s += ", Fixed"
+	}
+	return s
 }
 
-// Equals compares a bar to itself. It returns true if both Weight and Unit are equal.
+// Equals compares a bar to itself. It returns true if all fields are equal.
 func (b Bar) Equals(c Bar) bool {
-	return (b.Weight == c.Weight) && (b.Unit == c.Unit)
+	return b == c
 }
 
 // Valid checks that Unit is valid and that Bar Weight != zero
@@ -64,5 +171,8 @@ func (b Bar) Valid() error {
 	if b.Weight <= 0 {
 		return ErrInvalidWeightBar
 	}
+	if !(b.Sleeve >= 0) || b.Sleeve > MaxSleeve {
+		return ErrInvalidSleeveBar
+	}
 	return nil
 }
@@ -1,6 +1,9 @@
 package gear
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestBar(t *testing.T) {
 	t.Parallel()
@@ -10,8 +13,9 @@ func TestBar(t *testing.T) {
 			input    Bar
 			expected string
 		}{
-			{MensBarLBS, "Weight: 45, Unit: LBS"},
-			{WomensBarKG, "Weight: 15, Unit: KG"},
+			{MensBarLBS, "Name: mens-lbs, Weight: 45, Unit: LBS, Sleeve: 415mm"},
+			{WomensBarKG, "Name: womens-kg, Weight: 15, Unit: KG, Sleeve: 320mm"},
+			{Bar{Weight: 30 * Precision, Unit: LBS, Fixed: true}, "Weight: 30, Unit: LBS, Fixed"},
 		}
 		for _, test := range tt {
 			if test.input.String() != test.expected {
@@ -28,8 +32,8 @@ func TestBar(t *testing.T) {
 			err      error
 		}{
 			{MensBarLBS, LBS, 45.0, nil},
-			{MensBarLBS, KG, 20.411656649988664, nil},
-			{WomensBarKG, LBS, 33.06933932775, nil},
+			{MensBarLBS, KG, 20.412, nil},
+			{WomensBarKG, LBS, 33.069, nil},
 			{WomensBarKG, KG, 15.0, nil},
 			{WomensBarKG, Unit(5), 0.0, ErrInvalidUnit},
 		}
@@ -38,7 +42,7 @@ func TestBar(t *testing.T) {
 			if err != test.err {
 				t.Error("expected error mismatch:", err, test.err)
 			}
-			if o != test.expected {
+			if o != NewWeight(test.expected) {
 				t.Error("match failed for", o, test.expected)
 			}
 		}
@@ -46,8 +50,8 @@ func TestBar(t *testing.T) {
 	t.Run("Equals", func(t *testing.T) {
 		t.Parallel()
 
-		lbsBar20 := Bar{Weight: 20, Unit: LBS}
-		kgBar20 := Bar{Weight: 20, Unit: KG}
+		lbsBar20 := Bar{Weight: 20 * Precision, Unit: LBS}
+		kgBar20 := Bar{Weight: 20 * Precision, Unit: KG}
 
 		tt := []struct {
 			bar      Bar
@@ -57,6 +61,7 @@ func TestBar(t *testing.T) {
 			{MensBarLBS, MensBarLBS, true},
 			{MensBarLBS, WomensBarKG, false},
 			{lbsBar20, kgBar20, false},
+			{TrainingBar, EZCurlBar, false},
 		}
 		for _, test := range tt {
 			if o := test.bar.Equals(test.comp); o != test.expected {
@@ -64,4 +69,47 @@ func TestBar(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			bar Bar
+			err error
+		}{
+			{TrapBar, nil},
+			{Bar{Weight: 45 * Precision, Unit: Unit(5)}, ErrInvalidUnitBar},
+			{Bar{Unit: LBS}, ErrInvalidWeightBar},
+			{Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: -1}, ErrInvalidSleeveBar},
+			{Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: math.Inf(1)}, ErrInvalidSleeveBar},
+			{Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: math.NaN()}, ErrInvalidSleeveBar},
+			{Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: 1e15}, ErrInvalidSleeveBar},
+		}
+		for _, test := range tt {
+			if err := test.bar.Valid(); err != test.err {
+				t.Error("unexpected error:", test.bar, err, test.err)
+			}
+		}
+	})
+}
+
+func TestBarFromName(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name     string
+		expected Bar
+		err      error
+	}{
+		{"mens-kg", MensBarKG, nil},
+		{"safety-squat", SafetySquatBar, nil},
+		{"yoke", Bar{}, ErrBarNotFound},
+		{"", Bar{}, ErrBarNotFound},
+	}
+	for _, test := range tt {
+		o, err := BarFromName(test.name)
+		if err != test.err {
+			t.Error("unexpected error:", test.name, err, test.err)
+		}
+		if !o.Equals(test.expected) {
+			t.Error("unexpected bar:", test.name, o, test.expected)
+		}
+	}
 }
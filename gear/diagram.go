@@ -0,0 +1,100 @@
+package gear
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const (
+	// diagramScale is the number of pixels drawn for every millimeter of gear.
+	diagramScale = 0.3
+	// diagramSleeve is the sleeve length, in millimeters, drawn for a bar without one.
+	diagramSleeve = 415
+	// diagramColor is the color of any plate without a standard color.
+	diagramColor = "#555555"
+)
+
+// plateStyle is the size, in millimeters, and color of a standard KG plate.
+type plateStyle struct {
+	weight    Weight
+	diameter  float64
+	thickness float64
+	color     string
+}
+
+// plateStyles are the standard KG plates, heaviest first. Bumpers and the 2.5 to 0.5 KG
+// change plates use the IWF colors, and the 1.25 and 0.25 KG plates are chrome like IPF
+// change plates.
+var plateStyles = []plateStyle{
+	{25 * Precision, 450, 67, "#d52b1e"},
+	{20 * Precision, 450, 54, "#0051ba"},
+	{15 * Precision, 450, 43, "#ffcd00"},
+	{10 * Precision, 450, 33, "#009a44"},
+	{5 * Precision, 230, 27, "#f4f4f4"},
+	{NewWeight(2.5), 210, 19, "#d52b1e"},
+	{2 * Precision, 190, 19, "#0051ba"},
+	{NewWeight(1.5), 175, 18, "#ffcd00"},
+	{NewWeight(1.25), 160, 16, "#c0c0c0"},
+	{1 * Precision, 160, 15, "#009a44"},
+	{NewWeight(0.5), 135, 12, "#f4f4f4"},
+	{NewWeight(0.25), 110, 10, "#c0c0c0"},
+}
+
+// style returns the plateStyle of a plate of Gear. Plates that aren't a standard KG
+// plate are sized like the heaviest standard plate they weigh at least as much as,
+// and are drawn without a standard color.
+func (g Gear) style(plate Weight) plateStyle {
+	kg, _ := ConvertFromTo(plate, g.Unit, KG)
+	s := plateStyles[len(plateStyles)-1]
+	for _, p := range plateStyles {
+		if kg >= p.weight {
+			s = p
+			break
+		}
+	}
+	if g.Unit != KG || kg != s.weight {
+		s.color = diagramColor
+	}
+	for _, st := range g.stock() {
+		if g.fromPlates(st.weight) == plate && st.thickness > 0 {
+			s.thickness = st.thickness
+		}
+	}
+	s.weight = plate
+	return s
+}
+
+// Diagram takes the plates for one side of the bar and returns an inline SVG of the
+// sleeve loaded with them, drawn to scale from the collar out. Plates of KG Gear are
+// colored by the standard plate colors, so the bar can be loaded at a glance.
+func (g Gear) Diagram(plates []Weight) template.HTML {
+	sleeve := g.Bar.Sleeve
+	if sleeve == 0 {
+		sleeve = diagramSleeve
+	}
+	styles := make([]plateStyle, 0, len(plates))
+	loaded := 0.0
+	for _, p := range stacked(plates) {
+		s := g.style(p)
+		styles = append(styles, s)
+		loaded += s.thickness
+	}
+	collar := 20.0
+	width := collar + max(sleeve, loaded)*diagramScale + 1
+	height := plateStyles[0].diameter*diagramScale + 2
+	middle := height / 2
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="diagram" xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%.1f" viewBox="0 0 %.1f %.1f">`, width, height, width, height)
+	fmt.Fprintf(&b, `<rect x="0" y="%.1f" width="%.1f" height="%.1f" fill="#999999"/>`, middle-6, collar, 12.0)
+	fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#bbbbbb"/>`, collar, middle-4, sleeve*diagramScale, 8.0)
+	x := collar
+	for _, s := range styles {
+		w, h := s.thickness*diagramScale, s.diameter*diagramScale
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%v" stroke="#000000" stroke-width="0.5"><title>%v %v</title></rect>`, x, middle-h/2, w, h, s.color, s.weight, g.Unit)
+		x += w
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
@@ -0,0 +1,65 @@
+package gear
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDiagram(t *testing.T) {
+	t.Parallel()
+	kg := Default(KG)
+	kg.Bar = MensBarKG
+	thick := kg
+	thick.Plates.SetThickness(20*Precision, 100)
+	lbs := Default(LBS)
+
+	tt := []struct {
+		gear     Gear
+		plates   []Weight
+		contains []string
+		excludes []string
+	}{
+		{kg, weights(2.5, 20, 25), []string{
+			`width="20.1"`, `width="16.2"`, `width="5.7"`,
+			`fill="#d52b1e"`, `fill="#0051ba"`, `<title>25 KG</title>`,
+		}, []string{diagramColor}},
+		{kg, nil, []string{`width="124.5"`}, []string{"<title>"}},
+		{thick, weights(20), []string{`width="30.0"`, `fill="#0051ba"`}, nil},
+		{lbs, weights(45), []string{`fill="` + diagramColor + `"`, `<title>45 LBS</title>`}, []string{`fill="#0051ba"`}},
+	}
+	for i, test := range tt {
+		d := string(test.gear.Diagram(test.plates))
+		for _, c := range test.contains {
+			if !strings.Contains(d, c) {
+				t.Error("expected diagram to contain:", i, c, d)
+			}
+		}
+		for _, c := range test.excludes {
+			if strings.Contains(d, c) {
+				t.Error("expected diagram not to contain:", i, c, d)
+			}
+		}
+		dec := xml.NewDecoder(strings.NewReader(d))
+		for {
+			_, err := dec.Token()
+			if err != nil {
+				if err != io.EOF {
+					t.Error("invalid svg:", i, err)
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestDiagramOrder(t *testing.T) {
+	t.Parallel()
+	g := Default(KG)
+	d := string(g.Diagram(weights(5, 25, 10)))
+	red, green, white := strings.Index(d, "#d52b1e"), strings.Index(d, "#009a44"), strings.Index(d, "#f4f4f4")
+	if red > green || green > white {
+		t.Error("expected plates heaviest first from the collar:", d)
+	}
+}
@@ -0,0 +1,174 @@
+package gear
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrInputLessThanImplements is an error message used to warn that a Rounding call
+	// requests a value that is less than the lightest of the implements.
+	ErrInputLessThanImplements = errors.New("input weight is less than the lightest implement")
+	ErrInvalidUnitFixedWeights = errors.New("invalid unit: fixed weights")
+	// ErrInvalidToleranceFixedWeights is returned for a negative Tolerance.
+	ErrInvalidToleranceFixedWeights = errors.New("invalid tolerance: fixed weights")
+)
+
+// DefaultDumbbellsLBS is a set of dumbbells from 5 to 100 LBS in steps of 5.
+var DefaultDumbbellsLBS = Plates{
+	Weights: weights(5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60, 65, 70, 75, 80, 85, 90, 95, 100),
+	Unit:    LBS,
+}
+
+// DefaultKettlebellsKG is a set of competition kettlebells from 8 to 48 KG.
+var DefaultKettlebellsKG = Plates{
+	Weights: weights(8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48),
+	Unit:    KG,
+}
+
+// Equipment is anything a weight can be rounded to, such as Gear for a barbell or
+// a loadable dumbbell handle, or FixedWeights for dumbbells and kettlebells.
+type Equipment interface {
+	Min() (Weight, error)
+	Round(weight Weight) (Weight, error)
+	RoundWithin(weight, limit Weight) (Weight, error)
+	Recommend(weight Weight) ([]Weight, error)
+	Capacity() (Weight, bool, error)
+	Sequence(weights []Weight) ([]Load, error)
+}
+
+// FixedWeights are implements that can't be loaded, such as fixed dumbbells or
+// kettlebells. Implements holds every weight on hand, and like Gear weights are
+// rounded by the Rounding and returned in the units of Unit.
+type FixedWeights struct {
+	Implements Plates   `json:"implements"`
+	Unit       Unit     `json:"unit"`
+	Rounding   Rounding `json:"rounding"`
+	Tolerance  float64  `json:"tolerance,omitempty"`
+}
+
+// String outputs the string format for FixedWeights.
+func (f FixedWeights) String() string {
+	s := fmt.Sprintf("Unit: %v, Implements: { %v }", f.Unit, f.Implements)
+	if f.Rounding != Floor {
+		s += fmt.Sprintf(", Rounding: %v", f.Rounding)
+	}
+	if f.Tolerance != 0 {
+		s += fmt.Sprintf(", Tolerance: %v%%", f.Tolerance)
+	}
+	return s
+}
+
+// Valid checks the unit, rounding, tolerance and implements.
+func (f FixedWeights) Valid() error {
+	if !f.Unit.Valid() {
+		return ErrInvalidUnitFixedWeights
+	}
+	if !f.Rounding.Valid() {
+		return ErrInvalidRounding
+	}
+	if f.Tolerance < 0 {
+		return ErrInvalidToleranceFixedWeights
+	}
+	return f.Implements.Valid()
+}
+
+// weights returns the implements converted to the units of FixedWeights,
+// sorted lightest first.
+func (f FixedWeights) weights() ([]Weight, error) {
+	if err := f.Valid(); err != nil {
+		return nil, err
+	}
+	w := make([]Weight, 0, len(f.Implements.Weights))
+	for _, i := range tidy(f.Implements.Weights) {
+		c, err := ConvertFromTo(i, f.Implements.Unit, f.Unit)
+		if err != nil {
+			return nil, err
+		}
+		w = append(w, c)
+	}
+	sort.Slice(w, func(i, j int) bool { return w[i] < w[j] })
+	return w, nil
+}
+
+// Min returns the lightest implement in the units of FixedWeights.
+func (f FixedWeights) Min() (Weight, error) {
+	w, err := f.weights()
+	if err != nil {
+		return 0, err
+	}
+	return w[0], nil
+}
+
+// Round takes a weight in the units of FixedWeights and returns the weight of the
+// implement it rounds to.
+func (f FixedWeights) Round(weight Weight) (Weight, error) {
+	l, err := f.Load(weight)
+	return l.Weight, err
+}
+
+// RoundWithin rounds a weight the same as Round, but when the Rounding is
+// Capped it is never rounded up above limit instead of the Tolerance.
+func (f FixedWeights) RoundWithin(weight, limit Weight) (Weight, error) {
+	l, err := f.load(weight, limit)
+	return l.Weight, err
+}
+
+// Recommend always returns no plates, since implements can't be loaded.
+func (f FixedWeights) Recommend(weight Weight) ([]Weight, error) {
+	l, err := f.Load(weight)
+	return l.Plates, err
+}
+
+// Capacity returns the heaviest implement, the boolean is always true.
+func (f FixedWeights) Capacity() (Weight, bool, error) {
+	w, err := f.weights()
+	if err != nil {
+		return 0, false, err
+	}
+	return w[len(w)-1], true, nil
+}
+
+// Sequence takes weights in the units of FixedWeights and returns a Load for each of
+// them, rounded the same as Round.
+func (f FixedWeights) Sequence(weights []Weight) ([]Load, error) {
+	var loads []Load
+	for _, w := range weights {
+		l, err := f.Load(w)
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, l)
+	}
+	return loads, nil
+}
+
+// Load takes a weight in the units of FixedWeights and returns the Load of the
+// implement it rounds to by the Rounding. It returns ErrInputLessThanImplements
+// when the weight is less than the lightest implement.
+func (f FixedWeights) Load(weight Weight) (Load, error) {
+	return f.load(weight, weight.Mul(1+f.Tolerance/100))
+}
+
+// load returns the Load for a weight, limit is the heaviest weight Capped rounding
+// is allowed to round up to.
+func (f FixedWeights) load(weight, limit Weight) (Load, error) {
+	w, err := f.weights()
+	if err != nil {
+		return Load{}, err
+	}
+	if weight < w[0] {
+		return Load{}, ErrInputLessThanImplements
+	}
+	// below is the heaviest implement at or under the weight.
+	below := sort.Search(len(w), func(i int) bool { return w[i] > weight }) - 1
+	above := below
+	if w[below] < weight {
+		above++
+	}
+	if above < len(w) && f.Rounding.up(weight, w[below], w[above], limit, true) {
+		return Load{Weight: w[above]}, nil
+	}
+	return Load{Weight: w[below]}, nil
+}
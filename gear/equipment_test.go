@@ -0,0 +1,97 @@
+package gear
+
+import "testing"
+
+func TestFixedWeights(t *testing.T) {
+	t.Parallel()
+	var _ Equipment = Gear{}
+	var _ Equipment = FixedWeights{}
+
+	dumbbells := FixedWeights{Implements: DefaultDumbbellsLBS, Unit: LBS}
+	nearest := dumbbells
+	nearest.Rounding = Nearest
+	capped := dumbbells
+	capped.Rounding = Capped
+	capped.Tolerance = 5
+	kettlebells := FixedWeights{Implements: DefaultKettlebellsKG, Unit: LBS}
+	sixteen, _ := ConvertFromTo(16*Precision, KG, LBS)
+	twenty, _ := ConvertFromTo(20*Precision, KG, LBS)
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		f := FixedWeights{Implements: Plates{Weights: weights(5, 10), Unit: LBS}, Unit: KG, Rounding: Ceiling}
+		if s := f.String(); s != "Unit: KG, Implements: { Weights: [5 10], Unit: LBS }, Rounding: ceiling" {
+			t.Error("unexpected string:", s)
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input FixedWeights
+			err   error
+		}{
+			{dumbbells, nil},
+			{FixedWeights{Implements: DefaultDumbbellsLBS, Unit: Unit(5)}, ErrInvalidUnitFixedWeights},
+			{FixedWeights{Implements: DefaultDumbbellsLBS, Rounding: Rounding(9)}, ErrInvalidRounding},
+			{FixedWeights{Implements: DefaultDumbbellsLBS, Tolerance: -1}, ErrInvalidToleranceFixedWeights},
+			{FixedWeights{Implements: Plates{Unit: LBS}}, ErrNoPlatesFound},
+		}
+		for i, test := range tt {
+			if err := test.input.Valid(); err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			}
+		}
+	})
+	t.Run("Round", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    FixedWeights
+			weight   Weight
+			expected Weight
+			err      error
+		}{
+			{dumbbells, 5 * Precision, 5 * Precision, nil},
+			{dumbbells, 34 * Precision, 30 * Precision, nil},
+			{dumbbells, 35 * Precision, 35 * Precision, nil},
+			{dumbbells, 500 * Precision, 100 * Precision, nil},
+			{dumbbells, 4 * Precision, 0, ErrInputLessThanImplements},
+			{nearest, 33 * Precision, 35 * Precision, nil},
+			{nearest, NewWeight(32.5), 30 * Precision, nil},
+			{capped, 33 * Precision, 30 * Precision, nil},
+			{capped, 34 * Precision, 35 * Precision, nil},
+			{kettlebells, 40 * Precision, sixteen, nil},
+			{kettlebells, 45 * Precision, twenty, nil},
+		}
+		for i, test := range tt {
+			o, err := test.input.Round(test.weight)
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			}
+			if o != test.expected {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+	})
+	t.Run("Equipment", func(t *testing.T) {
+		t.Parallel()
+		if min, err := kettlebells.Min(); err != nil || min != NewWeight(17.637) {
+			t.Error("unexpected min:", min, err)
+		}
+		if max, ok, err := dumbbells.Capacity(); err != nil || !ok || max != 100*Precision {
+			t.Error("unexpected capacity:", max, ok, err)
+		}
+		if r, err := dumbbells.RoundWithin(33*Precision, 40*Precision); err != nil || r != 30*Precision {
+			t.Error("unexpected round within:", r, err)
+		}
+		if p, err := dumbbells.Recommend(33 * Precision); err != nil || p != nil {
+			t.Error("unexpected recommendation:", p, err)
+		}
+		loads, err := dumbbells.Sequence(weights(22, 33, 44))
+		if err != nil || len(loads) != 3 || loads[0].Weight != 20*Precision || loads[1].Weight != 30*Precision || loads[2].Weight != 40*Precision {
+			t.Error("unexpected sequence:", loads, err)
+		}
+		if _, err := dumbbells.Sequence(weights(2)); err != ErrInputLessThanImplements {
+			t.Error("unexpected error:", err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package gear
 import (
 	"errors"
 	"fmt"
+	"sort"
 )
 
 var (
@@ -10,16 +11,36 @@ var (
 	// a value that is less than the weight of the empty bar, itself.
 	ErrInputLessThanBar = errors.New("input weight is less than the minimum weight of the bar")
 	ErrInvalidUnitGear  = errors.New("invalid unit: gear")
+	// ErrInsufficientPlates is returned when a weight is heavier than can be
+	// loaded with the plates on hand.
+	ErrInsufficientPlates = errors.New("insufficient plates to load weight")
+	// ErrInsufficientSleeve is returned when a weight is heavier than the plates
+	// that fit on the sleeves of the bar.
+	ErrInsufficientSleeve = errors.New("insufficient sleeve to load weight")
+	// ErrInvalidToleranceGear is returned for a negative Tolerance.
+	ErrInvalidToleranceGear = errors.New("invalid tolerance: gear")
+	// ErrInvalidAttachmentsGear is returned when the Attachments take away
+	// at least as much load as the bar weighs.
+	ErrInvalidAttachmentsGear = errors.New("invalid attachments: gear")
 )
 
 // Gear is a struct that represents the weight inputs for the Bar, Plates, and desired unites
 // for the Gear to be measured in. Gear is primary used to calculate possible weight totals
 // from a requested weight amount, and is meant to be used to convert a lift from a calculated
 // percentage into the greatest possible incremental weight with the equipment provided.
+// Rounding sets how a weight is rounded, and Tolerance is the percent of a weight that
+// Capped rounding is allowed to round up over it, so a Tolerance of 2 caps 100 at 102.
+// Extra holds any plates in a unit other than Plates, such as KG change plates in an
+// LBS gym. Attachments are always on the bar, so they count toward every weight along
+// with the bar.
 type Gear struct {
-	Bar    Bar    `json:"bar"`
-	Plates Plates `json:"plates"`
-	Unit   Unit   `json:"unit"`
+	Bar         Bar          `json:"bar"`
+	Plates      Plates       `json:"plates"`
+	Extra       []Plates     `json:"extra,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Unit        Unit         `json:"unit"`
+	Rounding    Rounding     `json:"rounding"`
+	Tolerance   float64      `json:"tolerance,omitempty"`
 }
 
 // Default is a helper function to make it easy to setup default
@@ -45,13 +66,37 @@ func Default(unit Unit) Gear {
 
 // String outputs the string format for Gear.
 func (g Gear) String() string {
-	return fmt.Sprintf("Unit: %v, Bar: { %v }, Plates: { %v }", g.Unit, g.Bar, g.Plates)
+	s := fmt.Sprintf("Unit: %v, Bar: { %v }, Plates: { %v }", g.Unit, g.Bar, g.Plates)
+	for _, e := range g.Extra {
+		s += fmt.Sprintf(", Extra: { %v }", e)
+	}
+	for _, a := range g.Attachments {
+		s += fmt.Sprintf(", Attachment: { %v }", a)
+	}
+	if g.Rounding != Floor {
+		s += fmt.Sprintf(", Rounding: %v", g.Rounding)
+	}
+	if g.Tolerance != 0 {
+		s += fmt.Sprintf(", Tolerance: %v%%", g.Tolerance)
+	}
+	return s
 }
 
 // Min returns the minimum amount allowed for rounding. This is based
-// on the bar weight converted to the Gear Units.
-func (g Gear) Min() (float64, error) {
-	return g.Bar.ConvertTo(g.Unit)
+// on the bar weight and any Attachments converted to the Gear Units.
+func (g Gear) Min() (Weight, error) {
+	min, err := g.Bar.ConvertTo(g.Unit)
+	if err != nil {
+		return 0, err
+	}
+	for _, a := range g.Attachments {
+		w, err := a.ConvertTo(g.Unit)
+		if err != nil {
+			return 0, err
+		}
+		min += w
+	}
+	return min, nil
 }
 
 // Valid checks gear, plates, and bar for valid units and
@@ -60,59 +105,244 @@ func (g Gear) Valid() error {
 	if !g.Unit.Valid() {
 		return ErrInvalidUnitGear
 	}
+	if !g.Rounding.Valid() {
+		return ErrInvalidRounding
+	}
+	if g.Tolerance < 0 {
+		return ErrInvalidToleranceGear
+	}
 	if err := g.Bar.Valid(); err != nil {
 		return err
 	}
+	for _, e := range g.Extra {
+		if err := e.Valid(); err != nil {
+			return err
+		}
+	}
+	for _, a := range g.Attachments {
+		if err := a.Valid(); err != nil {
+			return err
+		}
+	}
+	if min, _ := g.Min(); min <= 0 {
+		return ErrInvalidAttachmentsGear
+	}
 	return g.Plates.Valid()
 }
 
-// Round takes a float64 number and returns the rounded total
+// Round takes a Weight and returns the rounded total
 // based on the bar and incremental plate weights, converted to
 // the gear units. If the bar and incremental plates are in KG
-// but the Gear units are LBS, the float64 will be returned in gear units
-// of LBS.
-func (g Gear) Round(weight float64) (float64, error) {
+// but the Gear units are LBS, the Weight will be returned in gear units
+// of LBS. The weight is rounded by the Rounding of the Gear and the rounded
+// total always matches the plates from Recommend.
+func (g Gear) Round(weight Weight) (Weight, error) {
+	l, err := g.Load(weight, FewestPlates, nil)
+	return l.Weight, err
+}
+
+// RoundWithin rounds a weight the same as Round, but when the Rounding is
+// Capped it is never rounded up above limit instead of the Tolerance.
+func (g Gear) RoundWithin(weight, limit Weight) (Weight, error) {
+	l, err := g.load(weight, limit, FewestPlates, nil)
+	return l.Weight, err
+}
+
+// barFromWeight takes a weight and returns the bar and plate weight
+// in the units of Gear or returns an error. The bar weight includes any
+// Attachments.
+func (g Gear) barFromWeight(weight Weight) (b, p Weight, err error) {
+	bar, _ := g.Min()
+	if weight < bar {
+		return b, p, ErrInputLessThanBar
+	}
+	return bar, weight - bar, nil
+}
+
+// Recommend takes a weight in the units of Gear and returns the plates to load
+// on one side of the bar, limited to the plates in the Inventory. Plates are
+// returned in the units of Gear.
+func (g Gear) Recommend(weight Weight) ([]Weight, error) {
+	l, err := g.Load(weight, FewestPlates, nil)
+	return l.Plates, err
+}
+
+// Load takes a weight in the units of Gear and returns the Load, rounded by the
+// Rounding of the Gear, that can be built with the bar and plates. When more than one
+// combination of plates reaches that weight the Policy picks between them, previous
+// holds the plates for one side of the bar from the set before and is only used
+// by FewestChanges. It returns ErrInsufficientPlates when the weight is out of reach
+// of the Inventory by more than the smallest pair of plates, and ErrInsufficientSleeve
+// when it is out of reach of the plates that fit on the sleeves. A Fixed bar is never
+// loaded, so any weight at or above the bar rounds to the bar.
+func (g Gear) Load(weight Weight, policy Policy, previous []Weight) (Load, error) {
+	return g.load(weight, weight.Mul(1+g.Tolerance/100), policy, previous)
+}
+
+// load returns the Load for a weight, limit is the heaviest weight Capped rounding
+// is allowed to round up to.
+func (g Gear) load(weight, limit Weight, policy Policy, previous []Weight) (Load, error) {
 	if err := g.Valid(); err != nil {
-		return 0, err
+		return Load{}, err
 	}
 	bar, plates, err := g.barFromWeight(weight)
 	if err != nil {
-		return 0, err
+		return Load{}, err
+	}
+	if weight == bar || g.Bar.Fixed {
+		return Load{Weight: bar}, nil
 	}
-	if weight == bar {
-		return weight, nil
+	st := g.stock()
+	target := g.toPlates(plates)
+	if max, ok := capacity(st); ok && target-max >= st[len(st)-1].weight*2 {
+		return Load{}, ErrInsufficientPlates
 	}
-	p, _ := ConvertFromTo(plates, g.Unit, g.Plates.Unit)
-	pr, _ := g.Plates.Round(p)
-	r, _ := ConvertFromTo(pr, g.Plates.Unit, g.Unit)
-	return bar + r, nil
+	if side, ok := fit(g.Bar.Sleeve, st); ok && target-side*2 >= st[len(st)-1].weight*2 {
+		return Load{}, ErrInsufficientSleeve
+	}
+	sol, err := solve(target, st, g.Bar.Sleeve, policy, g.previous(st, previous))
+	if err != nil {
+		return Load{}, err
+	}
+	below, above := g.fromPlates(sum(sol.below)*2), g.fromPlates(sum(sol.above)*2)
+	if g.Rounding.up(weight, bar+below, bar+above, limit, sol.over) {
+		return Load{Weight: bar + above, Plates: g.platesFromPlates(sol.above)}, nil
+	}
+	return Load{Weight: bar + below, Plates: g.platesFromPlates(sol.below)}, nil
 }
 
-// barFromWeight takes a weight and returns the bar and plate weight
-// in the units of Gear or returns an error.
-func (g Gear) barFromWeight(weight float64) (b, p float64, err error) {
-	bar, _ := g.Min()
-	if weight-bar < -.0001 {
-		return b, p, ErrInputLessThanBar
+// toPlates converts a weight in the units of Gear to the unit of the Plates, which the
+// plates are solved in, rounded down so it is never more than the weight.
+func (g Gear) toPlates(w Weight) Weight {
+	p, _ := ConvertFromTo(w, g.Unit, g.Plates.Unit)
+	for p > 0 && g.fromPlates(p) > w {
+		p--
 	}
-	return bar, weight - bar, nil
+	return p
+}
+
+// fromPlates converts a weight in the unit of the Plates to the units of Gear.
+func (g Gear) fromPlates(w Weight) Weight {
+	c, _ := ConvertFromTo(w, g.Plates.Unit, g.Unit)
+	return c
+}
+
+// platesFromPlates converts plates in the unit of the Plates to the units of Gear.
+func (g Gear) platesFromPlates(plates []Weight) []Weight {
+	var c []Weight
+	for _, p := range plates {
+		c = append(c, g.fromPlates(p))
+	}
+	return c
 }
 
-// Recommend takes
-func (g Gear) Recommend(weight float64) ([]float64, error) {
+// previous returns the stock weights of previous plates in the units of Gear.
+func (g Gear) previous(st []stock, previous []Weight) []Weight {
+	var p []Weight
+	for _, w := range previous {
+		for _, s := range st {
+			if g.fromPlates(s.weight) == w {
+				p = append(p, s.weight)
+				break
+			}
+		}
+	}
+	return p
+}
+
+// stock returns the Plates and Extra plates in the unit of the Plates, sorted heaviest
+// first. Plates are solved in their own unit, so converting them to the units of Gear
+// doesn't leave them a thousandth off each other. Matching weights from more than one
+// set of plates are combined, keeping the thickest plate. Extra plates in another unit
+// are snapped to the closest stockResolution, so a change plate doesn't shrink the
+// steps of the solver.
+func (g Gear) stock() []stock {
+	var st []stock
+	for _, p := range append([]Plates{g.Plates}, g.Extra...) {
+	plates:
+		for _, s := range p.stock() {
+			if p.Unit != g.Plates.Unit {
+				s.weight, _ = ConvertFromTo(s.weight, p.Unit, g.Plates.Unit)
+				s.weight = max((s.weight+stockResolution/2)/stockResolution*stockResolution, stockResolution)
+			}
+			for i := range st {
+				if st[i].weight != s.weight {
+					continue
+				}
+				if st[i].pairs < 0 || s.pairs < 0 {
+					st[i].pairs = -1
+				} else {
+					st[i].pairs += s.pairs
+				}
+				st[i].thickness = max(st[i].thickness, s.thickness)
+				continue plates
+			}
+			st = append(st, s)
+		}
+	}
+	sort.Slice(st, func(i, j int) bool { return st[i].weight > st[j].weight })
+	return st
+}
+
+// Capacity returns the heaviest total weight that can be loaded on the bar, limited
+// by both the Inventory and the plates that fit on the sleeves. The boolean is false
+// when there is no limit.
+func (g Gear) Capacity() (Weight, bool, error) {
 	if err := g.Valid(); err != nil {
-		return nil, err
+		return 0, false, err
 	}
-	_, p, err := g.barFromWeight(weight)
-	if err != nil {
-		return nil, err
+	bar, _ := g.Min()
+	if g.Bar.Fixed {
+		return bar, true, nil
+	}
+	st := g.stock()
+	max, ok := capacity(st)
+	if side, fits := fit(g.Bar.Sleeve, st); fits && (!ok || side*2 < max) {
+		max, ok = side*2, true
+	}
+	if !ok {
+		return 0, false, nil
+	}
+	return bar + g.fromPlates(max), true, nil
+}
+
+// capacity returns the total weight of every plate that can be loaded in pairs,
+// the boolean is false when any of the plates are unlimited.
+func capacity(st []stock) (Weight, bool) {
+	var total Weight
+	for _, s := range st {
+		if s.pairs < 0 {
+			return 0, false
+		}
+		total += Weight(s.pairs) * s.weight * 2
 	}
-	p, _ = ConvertFromTo(p, g.Unit, g.Plates.Unit)
-	return Recommend(p, g.Plates.Weights)
+	return total, len(st) > 0
 }
 
 // Equals checks for deep equality betten a comparable gear struct and itself
 // and returns a boolean value.
 func (g Gear) Equals(c Gear) bool {
-	return g.Bar.Equals(c.Bar) && g.Plates.Equals(c.Plates) && g.Unit == c.Unit
+	if len(g.Extra) != len(c.Extra) || len(g.Attachments) != len(c.Attachments) {
+		return false
+	}
+	for i := range g.Attachments {
+		if g.Attachments[i] != c.Attachments[i] {
+			return false
+		}
+	}
+	for i := range g.Extra {
+		if !g.Extra[i].Equals(c.Extra[i]) {
+			return false
+		}
+	}
+	return g.Bar.Equals(c.Bar) && g.Plates.Equals(c.Plates) && g.Unit == c.Unit &&
+		g.Rounding == c.Rounding && g.Tolerance == c.Tolerance
+}
+
+// sum adds up a slice of Weight.
+func sum(input []Weight) (total Weight) {
+	for _, x := range input {
+		total += x
+	}
+	return total
 }
@@ -43,7 +43,7 @@ func TestGear(t *testing.T) {
 				Unit:   KG,
 				Bar:    MensBarLBS,
 				Plates: Plates{Weights: DefaultWeightsKB, Unit: KG},
-			}, "Unit: KG, Bar: { Weight: 45, Unit: LBS }, Plates: { Weights: [1.25 2.5 5 10 15 20], Unit: KG }"},
+			}, "Unit: KG, Bar: { Name: mens-lbs, Weight: 45, Unit: LBS, Sleeve: 415mm }, Plates: { Weights: [1.25 2.5 5 10 15 20], Unit: KG }"},
 		}
 		for _, test := range tt {
 			if test.input.String() != test.expected {
@@ -62,24 +62,39 @@ func TestGear(t *testing.T) {
 				Unit:   LBS,
 				Bar:    MensBarLBS,
 				Plates: Plates{},
-			}, MensBarLBS.Weight, nil},
+			}, 45, nil},
 			{Gear{
 				Unit:   KG,
 				Bar:    MensBarLBS,
 				Plates: Plates{},
-			}, 20.411656649988664, nil},
+			}, 20.412, nil},
 			{Gear{
 				Unit:   Unit(5),
 				Bar:    MensBarLBS,
 				Plates: Plates{},
 			}, 0.0, ErrInvalidUnit},
+			{Gear{
+				Unit:        KG,
+				Bar:         MensBarKG,
+				Attachments: []Attachment{CompetitionCollarsKG},
+			}, 25, nil},
+			{Gear{
+				Unit:        LBS,
+				Bar:         MensBarLBS,
+				Attachments: []Attachment{{Weight: -10 * Precision, Unit: LBS}, {Weight: 2 * Precision, Unit: LBS}},
+			}, 37, nil},
+			{Gear{
+				Unit:        LBS,
+				Bar:         MensBarLBS,
+				Attachments: []Attachment{{Weight: 5 * Precision, Unit: Unit(5)}},
+			}, 0.0, ErrInvalidUnit},
 		}
 		for _, test := range tt {
 			o, err := test.input.Min()
 			if err != test.err {
 				t.Error("unexpected error:", err, test.err)
 			}
-			if o != test.expected {
+			if o != NewWeight(test.expected) {
 				t.Error("unexpected result:", o, test.expected)
 			}
 		}
@@ -104,44 +119,66 @@ func TestGear(t *testing.T) {
 			{Gear{
 				Unit:   LBS,
 				Bar:    MensBarLBS,
-				Plates: Plates{Weights: []float64{}, Unit: LBS},
+				Plates: Plates{Weights: []Weight{}, Unit: LBS},
 			}, 56, 0, ErrNoPlatesFound},
 			{Gear{
 				Unit:   Unit(5),
-				Bar:    Bar{Weight: 45, Unit: Unit(5)},
+				Bar:    Bar{Weight: 45 * Precision, Unit: Unit(5)},
 				Plates: Plates{},
 			}, 0, 0, ErrInvalidUnitGear},
 			{Gear{
 				Unit:   LBS,
-				Bar:    Bar{Weight: 45, Unit: Unit(5)},
+				Bar:    Bar{Weight: 45 * Precision, Unit: Unit(5)},
 				Plates: Plates{},
 			}, 0, 0, ErrInvalidUnitBar},
 			{Gear{
 				Unit:   LBS,
-				Bar:    Bar{Weight: -5, Unit: LBS},
+				Bar:    Bar{Weight: -5 * Precision, Unit: LBS},
 				Plates: Plates{},
 			}, 0, 0, ErrInvalidWeightBar},
 			{Gear{
 				Unit:   LBS,
 				Bar:    MensBarLBS,
-				Plates: Plates{Weights: []float64{}, Unit: Unit(5)},
+				Plates: Plates{Weights: []Weight{}, Unit: Unit(5)},
 			}, 89, 0, ErrInvalidUnitPlates},
 			{Gear{
 				Unit:   KG,
 				Bar:    MensBarKG,
-				Plates: Plates{Weights: []float64{2}, Unit: Unit(5)},
+				Plates: Plates{Weights: weights(2), Unit: Unit(5)},
 			}, 89, 0, ErrInvalidUnitPlates},
 			{Gear{
 				Unit:   KG,
 				Bar:    MensBarKG,
-				Plates: Plates{Weights: []float64{-1}, Unit: KG},
+				Plates: Plates{Weights: weights(-1), Unit: KG},
 			}, 89, 0, ErrInvalidWeightsPlates},
 		}
+		garage := Gear{
+			Unit:   LBS,
+			Bar:    MensBarLBS,
+			Plates: Plates{Weights: weights(10, 25, 45), Unit: LBS},
+		}
+		garage.Plates.SetCount(45*Precision, 2)
+		garage.Plates.SetCount(25*Precision, 2)
+		garage.Plates.SetCount(10*Precision, 4)
+		badInventory := garage
+		badInventory.Plates.Inventory = []Plate{{Weight: 100 * Precision, Count: 2}}
+		tt = append(tt, []struct {
+			gear     Gear
+			input    float64
+			expected float64
+			err      error
+		}{
+			{garage, 200, 185, nil},
+			{garage, 225, 225, nil},
+			{garage, 230, 225, nil},
+			{garage, 300, 0, ErrInsufficientPlates},
+			{badInventory, 200, 0, ErrInvalidInventoryPlates},
+		}...)
 		for i, test := range tt {
-			o, err := test.gear.Round(test.input)
+			o, err := test.gear.Round(NewWeight(test.input))
 			if err != test.err {
 				t.Error("unexpected error:", err, test.err, i)
-			} else if o != test.expected {
+			} else if o != NewWeight(test.expected) {
 				t.Error("unexpected result:", o, test.expected, i)
 			}
 		}
@@ -149,7 +186,7 @@ func TestGear(t *testing.T) {
 	t.Run("Recommend", func(t *testing.T) {
 		t.Parallel()
 		w := DefaultWeightsLBS
-		w = append(w, 1.25)
+		w = append(w, NewWeight(1.25))
 		g := Gear{
 			Unit:   LBS,
 			Bar:    MensBarLBS,
@@ -158,19 +195,37 @@ func TestGear(t *testing.T) {
 		tt := []struct {
 			gear     Gear
 			weight   float64
-			expected []float64
+			expected []Weight
 			err      error
 		}{
-			{g, 157.5, []float64{1.25, 10, 45}, nil},
-			{g, 44, []float64{}, ErrInputLessThanBar},
+			{g, 157.5, weights(1.25, 10, 45), nil},
+			{g, 44, []Weight{}, ErrInputLessThanBar},
 			{Gear{
 				Unit:   LBS,
 				Bar:    MensBarLBS,
-				Plates: Plates{Weights: []float64{}, Unit: Unit(5)},
-			}, 44, []float64{}, ErrInvalidUnitPlates},
+				Plates: Plates{Weights: []Weight{}, Unit: Unit(5)},
+			}, 44, []Weight{}, ErrInvalidUnitPlates},
+		}
+		garage := Gear{
+			Unit:   LBS,
+			Bar:    MensBarLBS,
+			Plates: Plates{Weights: weights(10, 25, 45), Unit: LBS},
 		}
+		garage.Plates.SetCount(45*Precision, 2)
+		garage.Plates.SetCount(25*Precision, 2)
+		garage.Plates.SetCount(10*Precision, 4)
+		tt = append(tt, []struct {
+			gear     Gear
+			weight   float64
+			expected []Weight
+			err      error
+		}{
+			{garage, 200, weights(25, 45), nil},
+			{garage, 225, weights(10, 10, 25, 45), nil},
+			{garage, 300, []Weight{}, ErrInsufficientPlates},
+		}...)
 		for _, test := range tt {
-			o, err := test.gear.Recommend(test.weight)
+			o, err := test.gear.Recommend(NewWeight(test.weight))
 			if err != test.err {
 				t.Error("unexpected error:", err, test.err)
 			} else if !equal(o, test.expected) {
@@ -178,4 +233,209 @@ func TestGear(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Rounding", func(t *testing.T) {
+		t.Parallel()
+		g := func(r Rounding, tolerance float64) Gear {
+			d := Default(LBS)
+			d.Rounding = r
+			d.Tolerance = tolerance
+			return d
+		}
+		tt := []struct {
+			gear     Gear
+			input    float64
+			expected float64
+			err      error
+		}{
+			{g(Floor, 0), 139, 135, nil},
+			{g(Nearest, 0), 139, 140, nil},
+			{g(Nearest, 0), 136, 135, nil},
+			{g(Nearest, 0), 137.5, 135, nil},
+			{g(Ceiling, 0), 136, 140, nil},
+			{g(Ceiling, 0), 135, 135, nil},
+			{g(Capped, 0.5), 139, 135, nil},
+			{g(Capped, 1), 139, 140, nil},
+			{g(Rounding(9), 0), 139, 0, ErrInvalidRounding},
+			{g(Capped, -1), 139, 0, ErrInvalidToleranceGear},
+		}
+		for i, test := range tt {
+			o, err := test.gear.Round(NewWeight(test.input))
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			} else if o != NewWeight(test.expected) {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+		if o, _ := g(Capped, 10).RoundWithin(139*Precision, NewWeight(139.5)); o != 135*Precision {
+			t.Error("unexpected result for RoundWithin:", o)
+		}
+	})
+	t.Run("Extra", func(t *testing.T) {
+		t.Parallel()
+		g := Gear{
+			Unit:   LBS,
+			Bar:    MensBarLBS,
+			Plates: Plates{Weights: weights(45), Unit: LBS},
+			Extra:  []Plates{{Weights: weights(1.25), Unit: KG, Inventory: []Plate{{NewWeight(1.25), 2}}}},
+		}
+		// 1.25 kg is 2.756 lbs, snapped to the closest stockResolution.
+		change := NewWeight(2.75)
+		badExtra := g
+		badExtra.Extra = []Plates{{Weights: weights(1.25), Unit: Unit(5)}}
+
+		tt := []struct {
+			gear     Gear
+			weight   float64
+			expected Load
+			err      error
+		}{
+			{g, 140, Load{Weight: 135 * Precision, Plates: weights(45)}, nil},
+			{g, 141, Load{Weight: 135*Precision + 2*change, Plates: []Weight{change, 45 * Precision}}, nil},
+			{badExtra, 141, Load{}, ErrInvalidUnitPlates},
+		}
+		for i, test := range tt {
+			o, err := test.gear.Load(NewWeight(test.weight), FewestPlates, nil)
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			} else if o.Weight != test.expected.Weight || !equal(o.Plates, test.expected.Plates) {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+		if s := g.String(); s != "Unit: LBS, Bar: { Name: mens-lbs, Weight: 45, Unit: LBS, Sleeve: 415mm }, Plates: { Weights: [45], Unit: LBS }, Extra: { Weights: [1.25], Unit: KG, Inventory: [2x1.25] }" {
+			t.Error("unexpected string:", s)
+		}
+		if g.Equals(Default(LBS)) || !g.Equals(g) {
+			t.Error("unexpected equality")
+		}
+	})
+	t.Run("Attachments", func(t *testing.T) {
+		t.Parallel()
+		collars := Default(KG)
+		collars.Attachments = []Attachment{CompetitionCollarsKG}
+		bands := Default(LBS)
+		bands.Attachments = []Attachment{{Name: "bands", Weight: -30 * Precision, Unit: LBS}}
+		badAttachment := Default(KG)
+		badAttachment.Attachments = []Attachment{{Unit: KG}}
+		tooLight := Default(KG)
+		tooLight.Attachments = []Attachment{{Weight: -20 * Precision, Unit: KG}}
+
+		tt := []struct {
+			gear     Gear
+			weight   float64
+			expected Load
+			err      error
+		}{
+			{collars, 25, Load{Weight: 25 * Precision}, nil},
+			{collars, 62, Load{Weight: 60 * Precision, Plates: weights(2.5, 15)}, nil},
+			{collars, 24, Load{}, ErrInputLessThanBar},
+			{bands, 15, Load{Weight: 15 * Precision}, nil},
+			{bands, 110, Load{Weight: 110 * Precision, Plates: weights(2.5, 45)}, nil},
+			{badAttachment, 60, Load{}, ErrInvalidWeightAttachment},
+			{tooLight, 60, Load{}, ErrInvalidAttachmentsGear},
+		}
+		for i, test := range tt {
+			o, err := test.gear.Load(NewWeight(test.weight), FewestPlates, nil)
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			} else if o.Weight != test.expected.Weight || !equal(o.Plates, test.expected.Plates) {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+		if s := collars.String(); s != "Unit: KG, Bar: { Name: mens-kg, Weight: 20, Unit: KG, Sleeve: 415mm }, Plates: { Weights: [1.25 2.5 5 10 15 20], Unit: KG }, Attachment: { Name: competition collars, Weight: 5, Unit: KG }" {
+			t.Error("unexpected string:", s)
+		}
+		if collars.Equals(Default(KG)) || !collars.Equals(collars) {
+			t.Error("unexpected equality")
+		}
+	})
+	t.Run("Capacity", func(t *testing.T) {
+		t.Parallel()
+		iron := Plates{Weights: weights(10, 45), Unit: LBS}
+		iron.SetThickness(45*Precision, 100)
+		iron.SetThickness(10*Precision, 10)
+		sleeved := Gear{Unit: LBS, Bar: Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: 150}, Plates: iron}
+		limited := sleeved
+		limited.Plates.SetCount(10*Precision, 8)
+		limited.Plates.SetCount(45*Precision, 2)
+		unlimited := sleeved
+		unlimited.Bar.Sleeve = 0
+		fixed := sleeved
+		fixed.Bar.Fixed = true
+		bad := sleeved
+		bad.Unit = Unit(5)
+
+		tt := []struct {
+			gear     Gear
+			expected float64
+			ok       bool
+			err      error
+		}{
+			{sleeved, 345, true, nil},
+			{limited, 215, true, nil},
+			{unlimited, 0, false, nil},
+			{fixed, 45, true, nil},
+			{bad, 0, false, ErrInvalidUnitGear},
+		}
+		for i, test := range tt {
+			o, ok, err := test.gear.Capacity()
+			if err != test.err || o != NewWeight(test.expected) || ok != test.ok {
+				t.Error("unexpected result:", i, o, ok, err, test.expected, test.ok, test.err)
+			}
+		}
+
+		lt := []struct {
+			weight   float64
+			expected Load
+			err      error
+		}{
+			{135, Load{Weight: 135 * Precision, Plates: weights(45)}, nil},
+			{245, Load{Weight: 245 * Precision, Plates: weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10)}, nil},
+			{345, Load{Weight: 345 * Precision, Plates: weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10)}, nil},
+			{350, Load{Weight: 345 * Precision, Plates: weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10)}, nil},
+			{370, Load{}, ErrInsufficientSleeve},
+		}
+		for i, test := range lt {
+			o, err := sleeved.Load(NewWeight(test.weight), FewestPlates, nil)
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			} else if o.Weight != test.expected.Weight || !equal(o.Plates, test.expected.Plates) {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+	})
+	t.Run("Load", func(t *testing.T) {
+		t.Parallel()
+		g := Gear{
+			Unit:   KG,
+			Bar:    MensBarKG,
+			Plates: Plates{Weights: weights(1.25, 15, 20, 25), Unit: KG},
+		}
+		fixed := g
+		fixed.Bar = Bar{Name: "fixed", Weight: 30 * Precision, Unit: KG, Fixed: true}
+		tt := []struct {
+			gear     Gear
+			weight   float64
+			policy   Policy
+			previous []Weight
+			expected Load
+			err      error
+		}{
+			{g, 90, FewestPlates, nil, Load{Weight: 90 * Precision, Plates: weights(15, 20)}, nil},
+			{g, 92, FewestPlates, nil, Load{Weight: 90 * Precision, Plates: weights(15, 20)}, nil},
+			{g, 92.5, FewestPlates, nil, Load{Weight: NewWeight(92.5), Plates: weights(1.25, 15, 20)}, nil},
+			{g, 20, FewestPlates, nil, Load{Weight: 20 * Precision}, nil},
+			{g, 19, FewestPlates, nil, Load{}, ErrInputLessThanBar},
+			{g, 90, Policy(9), nil, Load{}, ErrInvalidPolicy},
+			{fixed, 90, FewestPlates, nil, Load{Weight: 30 * Precision}, nil},
+			{fixed, 29, FewestPlates, nil, Load{}, ErrInputLessThanBar},
+		}
+		for i, test := range tt {
+			o, err := test.gear.Load(NewWeight(test.weight), test.policy, test.previous)
+			if err != test.err {
+				t.Error("unexpected error:", i, err, test.err)
+			} else if o.Weight != test.expected.Weight || !equal(o.Plates, test.expected.Plates) {
+				t.Error("unexpected result:", i, o, test.expected)
+			}
+		}
+	})
 }
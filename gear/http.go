@@ -29,15 +29,39 @@ var (
 	ErrMissingPlatesQuery = errors.New("missing plates in query")
 	// ErrMissingBarQuery is an error when gear is missing a bar in the query.
 	ErrMissingBarQuery = errors.New("missing bar in query")
+	// ErrInvalidCountQuery is an error when a plate count in the query is malformed.
+	ErrInvalidCountQuery = errors.New("invalid plate count in query")
+	// ErrInvalidThicknessQuery is an error when a plate thickness in the query is malformed.
+	ErrInvalidThicknessQuery = errors.New("invalid plate thickness in query")
+	// ErrInvalidAttachmentQuery is an error when an attachment in the query is malformed.
+	ErrInvalidAttachmentQuery = errors.New("invalid attachment in query")
 )
 
 // options represent the input options for the gear form templates.
 type options struct {
-	Value   string
-	Name    string
-	Bars    []option
-	Plates  []option
-	Checked bool
+	Value     string
+	Name      string
+	Bars      []option
+	Plates    []option
+	Extra     []option
+	ExtraUnit string
+	Checked   bool
+}
+
+// form represents all of the inputs for the gear form template.
+type form struct {
+	Profiles    []option
+	Units       []options
+	Roundings   []option
+	Attachments []attachmentOption
+}
+
+// attachmentOption represents an attachment that can be added to the bar, Unit is
+// the unit of the Value.
+type attachmentOption struct {
+	Unit  string
+	Value string
+	Name  string
 }
 
 // option represents a Value, Name, and Checked boolean for the configurable options.
@@ -48,27 +72,80 @@ type option struct {
 }
 
 // ToValues takes a Gear and returns properly formatted url.Values to be used in a get URL.
+// Gear that matches a profile in the Profiles catalog is referenced by the profile name.
 func ToValues(g Gear) (url.Values, error) {
 	vals := make(url.Values)
+	if p, err := profileFromGear(g); err == nil {
+		vals.Set(namespace+".profile", p.Name)
+		return vals, nil
+	}
 	unit := strings.ToLower(g.Unit.String())
 	vals.Set(namespace+".unit", unit)
 	b, err := g.Bar.ConvertTo(g.Unit)
 	if err != nil {
 		return vals, err
 	}
-	vals.Add(namespace+".bar."+unit, fmt.Sprintf("%.2f", b))
+	vals.Add(namespace+".bar."+unit, b.String())
+	if c, err := BarFromName(g.Bar.Name); err == nil && c.Equals(g.Bar) {
+		vals.Add(namespace+".barname."+unit, g.Bar.Name)
+	} else if g.Bar.Sleeve > 0 {
+		vals.Set(namespace+".sleeve", fmt.Sprintf("%.2f", g.Bar.Sleeve))
+	}
+	// plates are kept in their own unit, converted they wouldn't be whole plate weights.
+	if !g.Plates.Unit.Valid() {
+		return vals, ErrInvalidUnit
+	}
+	pu := strings.ToLower(g.Plates.Unit.String())
+	if g.Plates.Unit != g.Unit {
+		vals.Set(namespace+".plateunit", pu)
+	}
 	for _, w := range g.Plates.Weights {
-		p, err := ConvertFromTo(w, g.Plates.Unit, g.Unit)
-		if err != nil {
-			return vals, err
+		vals.Add(namespace+".plate."+pu, w.String())
+	}
+	for _, i := range g.Plates.Inventory {
+		vals.Add(namespace+".count."+pu, fmt.Sprintf("%v:%d", i.Weight, i.Count))
+	}
+	for _, t := range g.Plates.Thicknesses {
+		vals.Add(namespace+".thickness."+pu, fmt.Sprintf("%v:%.2f", t.Weight, t.Thickness))
+	}
+	for _, e := range g.Extra {
+		u := strings.ToLower(e.Unit.String())
+		if !e.Unit.Valid() {
+			return vals, ErrInvalidUnit
+		}
+		for _, w := range e.Weights {
+			vals.Add(namespace+".extra."+u, w.String())
+		}
+		for _, i := range e.Inventory {
+			vals.Add(namespace+".extracount."+u, fmt.Sprintf("%v:%d", i.Weight, i.Count))
+		}
+		for _, t := range e.Thicknesses {
+			vals.Add(namespace+".extrathickness."+u, fmt.Sprintf("%v:%.2f", t.Weight, t.Thickness))
+		}
+	}
+	for _, a := range g.Attachments {
+		if !a.Unit.Valid() {
+			return vals, ErrInvalidUnit
 		}
-		vals.Add(namespace+".plate."+unit, fmt.Sprintf("%.2f", p))
+		u := strings.ToLower(a.Unit.String())
+		vals.Add(namespace+".attachment."+u, fmt.Sprintf("%v:%s", a.Weight, a.Name))
+	}
+	if g.Rounding != Floor {
+		vals.Set(namespace+".rounding", g.Rounding.String())
+	}
+	if g.Tolerance != 0 {
+		vals.Set(namespace+".tolerance", fmt.Sprintf("%.2f", g.Tolerance))
 	}
 	return vals, nil
 }
 
 // FromValues takes a set of values in `url.Values` format and returns gear and an error.
+// When the values name a profile, the Gear of the profile is returned and the rest of
+// the gear in the values is ignored.
 func FromValues(vals url.Values) (g Gear, err error) {
+	if p, ok, err := ProfileFromValues(vals); ok || err != nil {
+		return p.Gear, err
+	}
 	v := values(vals)
 
 	unit, err := v.unit()
@@ -79,16 +156,43 @@ func FromValues(vals url.Values) (g Gear, err error) {
 	if err != nil {
 		return g, err
 	}
+	extra, err := v.extra(unit)
+	if err != nil {
+		return g, err
+	}
 	bar, err := v.bar(unit)
 	if err != nil {
 		return g, err
 	}
+	attachments, err := v.attachments()
+	if err != nil {
+		return g, err
+	}
+	rounding, tolerance, err := v.rounding()
+	if err != nil {
+		return g, err
+	}
 	g.Unit = unit
 	g.Plates = plates
+	g.Extra = extra
 	g.Bar = bar
+	g.Attachments = attachments
+	g.Rounding = rounding
+	g.Tolerance = tolerance
 	return g, nil
 }
 
+// ProfileFromValues returns the profile from the Profiles catalog named in the values,
+// the boolean is false when the values don't name a profile.
+func ProfileFromValues(vals url.Values) (p Profile, ok bool, err error) {
+	name, ok := vals[namespace+".profile"]
+	if !ok || name[0] == "" {
+		return p, false, nil
+	}
+	p, err = ProfileFromName(name[0])
+	return p, err == nil, err
+}
+
 func (v values) unit() (u Unit, err error) {
 	units, ok := v[namespace+".unit"]
 	if !ok {
@@ -97,15 +201,50 @@ func (v values) unit() (u Unit, err error) {
 	return UnitFromString(strings.ToUpper(units[0]))
 }
 
+// plates returns the plates in the unit of the gear, or in the unit of gear.plateunit
+// when it is set.
 func (v values) plates(unit Unit) (p Plates, err error) {
-	plates, ok := v[namespace+".plate."+strings.ToLower(unit.String())]
+	if pu, ok := v[namespace+".plateunit"]; ok && pu[0] != "" {
+		if unit, err = UnitFromString(strings.ToUpper(pu[0])); err != nil {
+			return p, err
+		}
+	}
+	u := strings.ToLower(unit.String())
+	plates, ok := v[namespace+".plate."+u]
 	if !ok {
 		return p, ErrMissingPlatesQuery
 	}
+	return v.parsePlates(unit, plates, v[namespace+".count."+u], v[namespace+".thickness."+u])
+}
+
+// extra returns a set of Plates for every unit, other than the unit of the
+// gear, with extra plates in the query.
+func (v values) extra(gearUnit Unit) (extra []Plates, err error) {
+	for unit := Unit(0); unit.Valid(); unit++ {
+		if unit == gearUnit {
+			continue
+		}
+		u := strings.ToLower(unit.String())
+		plates, ok := v[namespace+".extra."+u]
+		if !ok {
+			continue
+		}
+		p, err := v.parsePlates(unit, plates, v[namespace+".extracount."+u], v[namespace+".extrathickness."+u])
+		if err != nil {
+			return nil, err
+		}
+		extra = append(extra, p)
+	}
+	return extra, nil
+}
+
+// parsePlates parses plate weights, plate counts in the form of weight:count and
+// plate thicknesses in the form of weight:thickness.
+func (v values) parsePlates(unit Unit, plates, counts, thicknesses []string) (p Plates, err error) {
 	l := len(plates)
-	pi := make([]float64, l)
+	pi := make([]Weight, l)
 	for i, plate := range plates {
-		f, err := strconv.ParseFloat(plate, 64)
+		f, err := ParseWeight(plate)
 		if err != nil {
 			return p, err
 		}
@@ -113,33 +252,127 @@ func (v values) plates(unit Unit) (p Plates, err error) {
 	}
 	p.Weights = tidy(pi)
 	p.Unit = unit
+	for _, count := range counts {
+		w, c, ok := strings.Cut(count, ":")
+		if !ok {
+			return p, ErrInvalidCountQuery
+		}
+		f, err := ParseWeight(w)
+		if err != nil {
+			return p, err
+		}
+		n, err := strconv.ParseUint(c, 10, 0)
+		if err != nil {
+			return p, err
+		}
+		if !contains(p.Weights, f) {
+			return p, ErrInvalidInventoryPlates
+		}
+		p.SetCount(f, uint(n))
+	}
+	for _, thickness := range thicknesses {
+		w, t, ok := strings.Cut(thickness, ":")
+		if !ok {
+			return p, ErrInvalidThicknessQuery
+		}
+		f, err := ParseWeight(w)
+		if err != nil {
+			return p, err
+		}
+		mm, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return p, err
+		}
+		if !contains(p.Weights, f) || !(mm > 0) || mm > MaxThickness {
+			return p, ErrInvalidThicknessPlates
+		}
+		p.SetThickness(f, mm)
+	}
 	return p, nil
 }
 
+// bar returns the bar from the Bars catalog when named in the query, otherwise
+// it returns a bar from its weight in the units of the gear and its optional
+// sleeve length.
 func (v values) bar(unit Unit) (b Bar, err error) {
-
-	w, ok := v[namespace+".bar."+strings.ToLower(unit.String())]
+	u := strings.ToLower(unit.String())
+	if name, ok := v[namespace+".barname."+u]; ok && name[0] != "" {
+		return BarFromName(name[0])
+	}
+	w, ok := v[namespace+".bar."+u]
 	if !ok {
 		return b, ErrMissingBarQuery
 	}
-	weight, err := strconv.ParseFloat(w[0], 64)
+	weight, err := ParseWeight(w[0])
 	if err != nil {
 		return b, err
 	}
 	b.Unit = unit
 	b.Weight = weight
-	return b, err
+	if sleeve, ok := v[namespace+".sleeve"]; ok && sleeve[0] != "" {
+		if b.Sleeve, err = strconv.ParseFloat(sleeve[0], 64); err != nil {
+			return b, err
+		}
+	}
+	return b, b.Valid()
+}
+
+// attachments returns every attachment in the query in the form of weight:name,
+// the name is optional.
+func (v values) attachments() (attachments []Attachment, err error) {
+	for unit := Unit(0); unit.Valid(); unit++ {
+		for _, a := range v[namespace+".attachment."+strings.ToLower(unit.String())] {
+			w, name, ok := strings.Cut(a, ":")
+			if !ok {
+				return nil, ErrInvalidAttachmentQuery
+			}
+			f, err := ParseWeight(w)
+			if err != nil {
+				return nil, err
+			}
+			attachments = append(attachments, Attachment{Name: name, Weight: f, Unit: unit})
+		}
+	}
+	return attachments, nil
+}
+
+// rounding returns the Rounding and Tolerance, both of which are optional and
+// default to Floor with no Tolerance.
+func (v values) rounding() (r Rounding, tolerance float64, err error) {
+	if rounding, ok := v[namespace+".rounding"]; ok && rounding[0] != "" {
+		if r, err = RoundingFromString(rounding[0]); err != nil {
+			return r, tolerance, err
+		}
+	}
+	if t, ok := v[namespace+".tolerance"]; ok && t[0] != "" {
+		if tolerance, err = strconv.ParseFloat(t[0], 64); err != nil {
+			return r, tolerance, err
+		}
+	}
+	return r, tolerance, nil
+}
+
+// barOption returns an option for a bar from the Bars catalog.
+func barOption(b Bar, name string) option {
+	return option{Value: b.Name, Name: fmt.Sprintf("%v (%v %v)", name, b.Weight, b.Unit)}
 }
 
 // FormFields returns an html snippet for choosing lifting gear for the submit form
 func FormFields() template.HTML {
+	specialtyBars := []option{
+		barOption(TrapBar, "Trap bar"),
+		barOption(SafetySquatBar, "Safety squat bar"),
+		barOption(EZCurlBar, "EZ curl bar"),
+		barOption(TechniqueBar, "Technique bar"),
+		barOption(TrainingBar, "Training bar"),
+	}
 	lbs := options{
 		Value: "lbs",
 		Name:  "LBS",
-		Bars: []option{
-			{Value: "45", Name: "45 LBS"},
-			{Value: "35", Name: "35 LBS"},
-		},
+		Bars: append([]option{
+			barOption(MensBarLBS, "Men's"),
+			barOption(WomensBarLBS, "Women's"),
+		}, specialtyBars...),
 		Plates: []option{
 			{Value: "1.25"},
 			{Value: "2.5", Checked: true},
@@ -151,15 +384,23 @@ func FormFields() template.HTML {
 			{Value: "45", Checked: true},
 			{Value: "100"},
 		},
-		Checked: true,
+		Extra: []option{
+			{Value: "0.25"},
+			{Value: "0.5"},
+			{Value: "1"},
+			{Value: "1.25"},
+			{Value: "2.5"},
+		},
+		ExtraUnit: "kg",
+		Checked:   true,
 	}
 	kg := options{
 		Value: "kg",
 		Name:  "KG",
-		Bars: []option{
-			{Value: "20", Name: "20 KG"},
-			{Value: "15", Name: "15 KG"},
-		},
+		Bars: append([]option{
+			barOption(MensBarKG, "Men's"),
+			barOption(WomensBarKG, "Women's"),
+		}, specialtyBars...),
 		Plates: []option{
 			{Value: "0.25"},
 			{Value: "0.5"},
@@ -172,10 +413,36 @@ func FormFields() template.HTML {
 			{Value: "25", Checked: true},
 			{Value: "50"},
 		},
+		Extra: []option{
+			{Value: "1.25"},
+			{Value: "2.5"},
+			{Value: "5"},
+		},
+		ExtraUnit: "lbs",
+	}
+
+	roundings := []option{
+		{Value: Floor.String(), Name: "Down", Checked: true},
+		{Value: Nearest.String(), Name: "Nearest"},
+		{Value: Ceiling.String(), Name: "Up"},
+		{Value: Capped.String(), Name: "Nearest (capped)"},
+	}
+
+	attachments := []attachmentOption{
+		{
+			Unit:  "kg",
+			Value: fmt.Sprintf("%v:%v", CompetitionCollarsKG.Weight, CompetitionCollarsKG.Name),
+			Name:  "Competition collars (2.5 KG each)",
+		},
+	}
+
+	profiles := []option{{Value: "", Name: "Custom", Checked: true}}
+	for _, p := range Profiles {
+		profiles = append(profiles, option{Value: p.Name, Name: p.Name})
 	}
 
 	t, _ := template.New(namespace).Parse(formTemplate)
 	var b bytes.Buffer
-	t.Execute(&b, []options{lbs, kg})
+	t.Execute(&b, form{Profiles: profiles, Units: []options{lbs, kg}, Roundings: roundings, Attachments: attachments})
 	return template.HTML(b.String())
 }
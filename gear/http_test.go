@@ -17,6 +17,18 @@ func TestToValues(t *testing.T) {
 	badBar.Bar.Unit = 5
 	badPlates := Default(LBS)
 	badPlates.Plates.Unit = 5
+	badExtra := Default(LBS)
+	badExtra.Extra = []Plates{{Weights: weights(1.25), Unit: 5}}
+	collars := Default(LBS)
+	collars.Attachments = []Attachment{CompetitionCollarsKG}
+	badAttachment := Default(LBS)
+	badAttachment.Attachments = []Attachment{{Weight: 5 * Precision, Unit: 5}}
+	custom := Default(LBS)
+	custom.Bar = Bar{Name: "mens-lbs", Weight: 30 * Precision, Unit: LBS}
+	stone := Default(LBS)
+	stone.Unit = STONE
+	stone.Bar = Bar{Weight: 3 * Precision, Unit: STONE}
+	stone.Plates = Plates{Weights: weights(0.5, 1), Unit: STONE}
 
 	tt := []struct {
 		gear Gear
@@ -24,12 +36,35 @@ func TestToValues(t *testing.T) {
 		err  error
 	}{
 		{goodGear, url.Values{
-			"gear.bar.lbs":   []string{"45.00"},
-			"gear.plate.lbs": []string{"2.50", "5.00", "10.00", "25.00", "35.00", "45.00"},
-			"gear.unit":      []string{"lbs"},
+			"gear.bar.lbs":     []string{"45"},
+			"gear.barname.lbs": []string{"mens-lbs"},
+			"gear.plate.lbs":   []string{"2.5", "5", "10", "25", "35", "45"},
+			"gear.unit":        []string{"lbs"},
 		}, nil},
 		{badBar, url.Values{}, ErrInvalidUnit},
 		{badPlates, url.Values{}, ErrInvalidUnit},
+		{badExtra, url.Values{}, ErrInvalidUnit},
+		{collars, url.Values{
+			"gear.bar.lbs":       []string{"45"},
+			"gear.barname.lbs":   []string{"mens-lbs"},
+			"gear.plate.lbs":     []string{"2.5", "5", "10", "25", "35", "45"},
+			"gear.unit":          []string{"lbs"},
+			"gear.attachment.kg": []string{"5:competition collars"},
+		}, nil},
+		{badAttachment, url.Values{}, ErrInvalidUnit},
+		{stone, url.Values{
+			"gear.bar.stone":   []string{"3"},
+			"gear.plate.stone": []string{"0.5", "1"},
+			"gear.unit":        []string{"stone"},
+		}, nil},
+		{HomeProfile.Gear, url.Values{
+			"gear.profile": []string{"home"},
+		}, nil},
+		{custom, url.Values{
+			"gear.bar.lbs":   []string{"30"},
+			"gear.plate.lbs": []string{"2.5", "5", "10", "25", "35", "45"},
+			"gear.unit":      []string{"lbs"},
+		}, nil},
 	}
 
 	for _, test := range tt {
@@ -65,9 +100,89 @@ func TestFromValues(t *testing.T) {
 	badPlatesVal.Add("gear.plate.lbs", "foo")
 	badBar, _ := ToValues(Default(LBS))
 	badBar.Del("gear.bar.lbs")
+	badBar.Del("gear.barname.lbs")
 	badBarVal, _ := ToValues(Default(LBS))
 	badBarVal["gear.bar.lbs"] = []string{"foo"}
+	badBarVal.Del("gear.barname.lbs")
 	badValErr := errors.New(`strconv.ParseFloat: parsing "foo": invalid syntax`)
+	limited := Default(LBS)
+	limited.Plates.SetCount(45*Precision, 2)
+	limited.Plates.SetCount(25*Precision, 4)
+	limitedVals, _ := ToValues(limited)
+	badCount, _ := ToValues(Default(LBS))
+	badCount.Add("gear.count.lbs", "45.00")
+	badCountVal, _ := ToValues(Default(LBS))
+	badCountVal.Add("gear.count.lbs", "45.00:foo")
+	badCountPlate, _ := ToValues(Default(LBS))
+	badCountPlate.Add("gear.count.lbs", "100.00:2")
+	capped := Default(LBS)
+	capped.Rounding = Capped
+	capped.Tolerance = 2.5
+	cappedVals, _ := ToValues(capped)
+	badRounding, _ := ToValues(Default(LBS))
+	badRounding.Set("gear.rounding", "sideways")
+	mixed := Default(LBS)
+	mixed.Extra = []Plates{{Weights: weights(0.5, 1.25), Unit: KG}}
+	mixed.Extra[0].SetCount(NewWeight(1.25), 2)
+	mixedVals, _ := ToValues(mixed)
+	sameUnit, _ := ToValues(Default(LBS))
+	sameUnit.Add("gear.extra.lbs", "1.25")
+	badExtra, _ := ToValues(Default(LBS))
+	badExtra.Add("gear.extra.kg", "foo")
+	attached := Default(LBS)
+	attached.Attachments = []Attachment{CompetitionCollarsKG, {Weight: -20 * Precision, Unit: LBS}}
+	attachedVals, _ := ToValues(attached)
+	badAttachment, _ := ToValues(Default(LBS))
+	badAttachment.Add("gear.attachment.lbs", "5.00")
+	badAttachmentVal, _ := ToValues(Default(LBS))
+	badAttachmentVal.Add("gear.attachment.lbs", "foo:chains")
+	trap := Default(LBS)
+	trap.Bar = TrapBar
+	trapVals, _ := ToValues(trap)
+	weighed := Default(LBS)
+	weighed.Bar = Bar{Weight: 45 * Precision, Unit: LBS}
+	weighedVals, _ := ToValues(Default(LBS))
+	weighedVals.Del("gear.barname.lbs")
+	badBarName, _ := ToValues(Default(LBS))
+	badBarName.Set("gear.barname.lbs", "yoke")
+	fitted := Default(LBS)
+	fitted.Bar = Bar{Weight: 45 * Precision, Unit: LBS, Sleeve: 415}
+	fitted.Plates.SetThickness(45*Precision, 60)
+	fitted.Extra = []Plates{{Weights: weights(1.25), Unit: KG}}
+	fitted.Extra[0].SetThickness(NewWeight(1.25), 15)
+	fittedVals, _ := ToValues(fitted)
+	badThickness, _ := ToValues(Default(LBS))
+	badThickness.Add("gear.thickness.lbs", "45.00")
+	badThicknessVal, _ := ToValues(Default(LBS))
+	badThicknessVal.Add("gear.thickness.lbs", "45.00:foo")
+	badThicknessPlate, _ := ToValues(Default(LBS))
+	badThicknessPlate.Add("gear.thickness.lbs", "100.00:60")
+	badSleeve, _ := ToValues(Default(LBS))
+	badSleeve.Del("gear.barname.lbs")
+	badSleeve.Set("gear.sleeve", "foo")
+	infSleeve := url.Values{"gear.unit": {"lbs"}, "gear.bar.lbs": {"45"}, "gear.plate.lbs": {"45"}, "gear.sleeve": {"Inf"}}
+	longSleeve := url.Values{"gear.unit": {"lbs"}, "gear.bar.lbs": {"45"}, "gear.plate.lbs": {"45"}, "gear.sleeve": {"1e15"}}
+	thickPlate, _ := ToValues(Default(LBS))
+	thickPlate.Add("gear.thickness.lbs", "45:+Inf")
+	pood := Gear{
+		Unit:   POOD,
+		Bar:    Bar{Weight: NewWeight(0.5), Unit: POOD},
+		Plates: Plates{Weights: weights(0.25, 0.5, 1), Unit: POOD},
+		Extra:  []Plates{{Weights: weights(0.5), Unit: STONE}},
+	}
+	poodVals, _ := ToValues(pood)
+	fractional := Default(KG)
+	fractional.Plates.Add(NewWeight(0.125))
+	fractionalVals, _ := ToValues(fractional)
+	profileVals, _ := ToValues(CompetitionKGProfile.Gear)
+	profileOverride, _ := ToValues(Default(LBS))
+	profileOverride.Set("gear.profile", "globo-gym")
+	customProfile, _ := ToValues(Default(LBS))
+	customProfile.Set("gear.profile", "")
+	badProfile, _ := ToValues(Default(LBS))
+	badProfile.Set("gear.profile", "moon base")
+	badTolerance, _ := ToValues(Default(LBS))
+	badTolerance.Set("gear.tolerance", "foo")
 
 	tt := []struct {
 		values   url.Values
@@ -77,9 +192,39 @@ func TestFromValues(t *testing.T) {
 		{invalid, Gear{}, ErrMissingUnitQuery},
 		{valid, Default(LBS), nil},
 		{badPlates, Gear{}, ErrMissingPlatesQuery},
-		{badPlatesVal, Gear{}, badValErr},
+		{badPlatesVal, Gear{}, ErrInvalidWeight},
 		{badBar, Gear{}, ErrMissingBarQuery},
-		{badBarVal, Gear{}, badValErr},
+		{badBarVal, Gear{}, ErrInvalidWeight},
+		{limitedVals, limited, nil},
+		{badCount, Gear{}, ErrInvalidCountQuery},
+		{badCountVal, Gear{}, errors.New(`strconv.ParseUint: parsing "foo": invalid syntax`)},
+		{badCountPlate, Gear{}, ErrInvalidInventoryPlates},
+		{cappedVals, capped, nil},
+		{badRounding, Gear{}, ErrInvalidRounding},
+		{badTolerance, Gear{}, badValErr},
+		{mixedVals, mixed, nil},
+		{sameUnit, Default(LBS), nil},
+		{badExtra, Gear{}, ErrInvalidWeight},
+		{attachedVals, attached, nil},
+		{badAttachment, Gear{}, ErrInvalidAttachmentQuery},
+		{badAttachmentVal, Gear{}, ErrInvalidWeight},
+		{trapVals, trap, nil},
+		{weighedVals, weighed, nil},
+		{badBarName, Gear{}, ErrBarNotFound},
+		{fittedVals, fitted, nil},
+		{poodVals, pood, nil},
+		{fractionalVals, fractional, nil},
+		{profileVals, CompetitionKGProfile.Gear, nil},
+		{profileOverride, GloboGymProfile.Gear, nil},
+		{customProfile, Default(LBS), nil},
+		{badProfile, Gear{}, ErrProfileNotFound},
+		{badThickness, Gear{}, ErrInvalidThicknessQuery},
+		{badThicknessVal, Gear{}, badValErr},
+		{badThicknessPlate, Gear{}, ErrInvalidThicknessPlates},
+		{badSleeve, Gear{}, badValErr},
+		{infSleeve, Gear{}, ErrInvalidSleeveBar},
+		{longSleeve, Gear{}, ErrInvalidSleeveBar},
+		{thickPlate, Gear{}, ErrInvalidThicknessPlates},
 	}
 
 	for _, test := range tt {
@@ -115,3 +260,37 @@ func TestFormFields(t *testing.T) {
 	}
 
 }
+
+func TestValuesRoundTrip(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		gear     Gear
+		weight   float64
+		expected float64
+	}{
+		// plates in another unit than the gear keep their own unit.
+		{Gear{Unit: KG, Bar: MensBarLBS, Plates: DefaultPlatesLBS}, 100, 99.791},
+		{Gear{Unit: STONE, Bar: MensBarLBS, Plates: DefaultPlatesLBS}, 12, 11.785},
+		{Gear{Unit: POOD, Bar: MensBarKG, Plates: DefaultPlatesKG}, 6, 5.952},
+		{Gear{Unit: STONE, Bar: Bar{Weight: 3 * Precision, Unit: STONE}, Plates: Plates{Weights: weights(0.5, 1), Unit: STONE}, Extra: []Plates{DefaultPlatesKG}}, 10.2, 10.2},
+	}
+	for i, test := range tt {
+		v, err := ToValues(test.gear)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := FromValues(v)
+		if err != nil {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if !g.Equals(test.gear) {
+			t.Error("unexpected gear:", i, g)
+		}
+		// converted plates are a thousandth off each other, they shouldn't be stacked
+		// up from the lightest plate to make up the difference.
+		if l, err := g.Load(NewWeight(test.weight), FewestPlates, nil); err != nil || l.Weight != NewWeight(test.expected) || len(l.Plates) > 4 {
+			t.Error("unexpected result:", i, l, err)
+		}
+	}
+}
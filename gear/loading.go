@@ -0,0 +1,155 @@
+package gear
+
+import (
+	"sort"
+)
+
+// maxCandidates is the number of loads carried from one weight to the next
+// when planning a Sequence.
+const maxCandidates = 4
+
+// maxExtraPlates is the number of plates a candidate load may have over the
+// fewest plates of its weight, so saving a change or two never stacks the bar
+// with the lightest plates.
+const maxExtraPlates = 1
+
+// Change is the plates removed from, and then added to, one side of the bar
+// to go from one Load to the next.
+type Change struct {
+	Load   []Weight `json:"load,omitempty"`
+	Unload []Weight `json:"unload,omitempty"`
+}
+
+// Count returns the total number of plates moved for one side of the bar.
+func (c Change) Count() int {
+	return len(c.Load) + len(c.Unload)
+}
+
+// Changes takes the plates for one side of the bar before and after and returns
+// the Change between them. Plates are stacked heaviest first against the collar,
+// so any plate outside of the shared inner stack has to come off the bar.
+// Unload is ordered from the outside in and Load from the inside out.
+func Changes(from, to []Weight) Change {
+	from, to = stacked(from), stacked(to)
+	i := 0
+	for i < len(from) && i < len(to) && from[i] == to[i] {
+		i++
+	}
+	var c Change
+	for j := len(from) - 1; j >= i; j-- {
+		c.Unload = append(c.Unload, from[j])
+	}
+	c.Load = append(c.Load, to[i:]...)
+	return c
+}
+
+// stacked returns a copy of the plates sorted heaviest first.
+func stacked(plates []Weight) []Weight {
+	s := append([]Weight(nil), plates...)
+	sort.Slice(s, func(i, j int) bool { return s[i] > s[j] })
+	return s
+}
+
+// Sequence takes weights in the units of Gear, in the order they are lifted,
+// and returns a Load for each of them. Every weight is rounded the same as Round,
+// but the plates are chosen to keep the number of plates loaded and unloaded
+// across the whole sequence low. It compares a few candidate loads of each weight,
+// so the plan is a good one and not always the one with the fewest Changes.
+func (g Gear) Sequence(weights []Weight) ([]Load, error) {
+	if len(weights) == 0 {
+		return nil, nil
+	}
+
+	// candidates holds the ways of loading each weight that are worth comparing,
+	// cost is the fewest plate changes to reach each candidate and from is the
+	// candidate of the weight before that it was reached from.
+	candidates := make([][]Load, len(weights))
+	cost := make([][]int, len(weights))
+	from := make([][]int, len(weights))
+
+	for i, w := range weights {
+		var previous [][]Weight
+		if i > 0 {
+			order := make([]int, len(candidates[i-1]))
+			for j := range order {
+				order[j] = j
+			}
+			sort.SliceStable(order, func(a, b int) bool { return cost[i-1][order[a]] < cost[i-1][order[b]] })
+			for j, o := range order {
+				if j == maxCandidates {
+					break
+				}
+				previous = append(previous, candidates[i-1][o].Plates)
+			}
+		}
+		loads, err := g.candidates(w, previous)
+		if err != nil {
+			return nil, err
+		}
+		candidates[i] = loads
+		cost[i] = make([]int, len(loads))
+		from[i] = make([]int, len(loads))
+		for k, l := range loads {
+			if i == 0 {
+				cost[i][k] = Changes(nil, l.Plates).Count()
+				continue
+			}
+			cost[i][k] = -1
+			for j, p := range candidates[i-1] {
+				c := cost[i-1][j] + Changes(p.Plates, l.Plates).Count()
+				if cost[i][k] < 0 || c < cost[i][k] {
+					cost[i][k] = c
+					from[i][k] = j
+				}
+			}
+		}
+	}
+
+	last := len(weights) - 1
+	k := 0
+	for j := range cost[last] {
+		if cost[last][j] < cost[last][k] {
+			k = j
+		}
+	}
+	loads := make([]Load, len(weights))
+	for i := last; i >= 0; i-- {
+		loads[i] = candidates[i][k]
+		k = from[i][k]
+	}
+	return loads, nil
+}
+
+// candidates returns the distinct loads of a weight for each Policy, solving
+// FewestChanges once for every set of previous plates. Loads with more than
+// maxExtraPlates over the FewestPlates load are left out.
+func (g Gear) candidates(weight Weight, previous [][]Weight) ([]Load, error) {
+	var loads []Load
+	add := func(policy Policy, p []Weight) error {
+		l, err := g.Load(weight, policy, p)
+		if err != nil {
+			return err
+		}
+		if len(loads) > 0 && len(l.Plates) > len(loads[0].Plates)+maxExtraPlates {
+			return nil
+		}
+		for _, c := range loads {
+			if equal(c.Plates, l.Plates) {
+				return nil
+			}
+		}
+		loads = append(loads, l)
+		return nil
+	}
+	for _, policy := range []Policy{FewestPlates, HeaviestFirst} {
+		if err := add(policy, nil); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range previous {
+		if err := add(FewestChanges, p); err != nil {
+			return nil, err
+		}
+	}
+	return loads, nil
+}
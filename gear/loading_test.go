@@ -0,0 +1,92 @@
+package gear
+
+import "testing"
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		from   []Weight
+		to     []Weight
+		load   []Weight
+		unload []Weight
+	}{
+		{nil, weights(10, 45), weights(45, 10), nil},
+		{weights(10, 45), nil, nil, weights(10, 45)},
+		{weights(10, 45), weights(25, 45), weights(25), weights(10)},
+		{weights(5, 25, 45), weights(10, 45, 45), weights(45, 10), weights(5, 25)},
+		{weights(45), weights(45), nil, nil},
+	}
+	for i, test := range tt {
+		c := Changes(test.from, test.to)
+		if !equal(c.Load, test.load) || !equal(c.Unload, test.unload) {
+			t.Error("unexpected change:", i, c, test.load, test.unload)
+		}
+		if c.Count() != len(test.load)+len(test.unload) {
+			t.Error("unexpected count:", i, c.Count())
+		}
+	}
+}
+
+func TestSequence(t *testing.T) {
+	t.Parallel()
+	g := Gear{
+		Unit:   KG,
+		Bar:    MensBarKG,
+		Plates: Plates{Weights: weights(5, 15, 20), Unit: KG},
+	}
+	tt := []struct {
+		weights  []Weight
+		expected [][]Weight
+		err      error
+	}{
+		{nil, nil, nil},
+		// solved one set at a time the 90kg set would be loaded with 20 + 15
+		// and both of the 15s from the 80kg set would need to come off.
+		{weights(80, 90), [][]Weight{weights(15, 15), weights(5, 15, 15)}, nil},
+		{weights(60, 80, 60), [][]Weight{weights(20), weights(5, 5, 20), weights(20)}, nil},
+		{weights(19), nil, ErrInputLessThanBar},
+	}
+	for i, test := range tt {
+		o, err := g.Sequence(test.weights)
+		if err != test.err {
+			t.Error("unexpected error:", i, err, test.err)
+			continue
+		}
+		if len(o) != len(test.expected) {
+			t.Error("unexpected length:", i, len(o), len(test.expected))
+			continue
+		}
+		for j, l := range o {
+			if !equal(l.Plates, test.expected[j]) {
+				t.Error("unexpected plates:", i, j, l.Plates, test.expected[j])
+			}
+			if r, _ := g.Round(test.weights[j]); r != l.Weight {
+				t.Error("sequence weight does not match Round:", i, j, l.Weight, r)
+			}
+		}
+	}
+}
+
+func TestSequencePlateCount(t *testing.T) {
+	t.Parallel()
+	g := Default(LBS)
+	// the warmup, working and joker sets of a bench press with a 200 lb training max,
+	// the 10s carried up from the warmup shouldn't end up stacked under every joker set.
+	w := weights(45, 50, 70, 90, 110, 130, 150, 170, 180, 190, 200, 210, 220, 230, 240)
+	o, err := g.Sequence(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, l := range o {
+		f, err := g.Load(w[i], FewestPlates, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(l.Plates) > len(f.Plates)+maxExtraPlates {
+			t.Error("too many plates:", w[i], l.Plates, f.Plates)
+		}
+	}
+	if l := o[len(o)-1]; !equal(l.Plates, weights(2.5, 5, 45, 45)) {
+		t.Error("unexpected plates:", l.Plates)
+	}
+}
@@ -7,9 +7,28 @@ import (
 )
 
 // Plates are are a set of plates and its corresponding unit.
+// Inventory optionally limits how many of each weight are on hand,
+// any weight without an Inventory entry is treated as unlimited.
+// Thicknesses optionally sets how thick each weight is, which is
+// used to check that plates fit on the sleeve of the bar.
 type Plates struct {
-	Weights []float64 `json:"weights"`
-	Unit    Unit      `json:"unit"`
+	Weights     []Weight    `json:"weights"`
+	Unit        Unit        `json:"unit"`
+	Inventory   []Plate     `json:"inventory,omitempty"`
+	Thicknesses []Thickness `json:"thicknesses,omitempty"`
+}
+
+// Plate is the number of plates owned for a single weight. Plates are loaded
+// in pairs, so a Count of 5 allows for 2 plates per side of the bar.
+type Plate struct {
+	Weight Weight `json:"weight"`
+	Count  uint   `json:"count"`
+}
+
+// Thickness is how thick a single plate of a weight is, in millimeters.
+type Thickness struct {
+	Weight    Weight  `json:"weight"`
+	Thickness float64 `json:"thickness"`
 }
 
 var (
@@ -17,27 +36,26 @@ var (
 	ErrNoPlatesFound        = errors.New("no plates found")
 	ErrInvalidUnitPlates    = errors.New("invalid unit: plates")
 	ErrInvalidWeightsPlates = errors.New("invalid weight: plates")
+	// ErrInvalidInventoryPlates is returned when an Inventory entry does not
+	// match any of the plate Weights.
+	ErrInvalidInventoryPlates = errors.New("invalid inventory: plates")
+	// ErrUnlimitedPlates is returned when asking for the maximum load of plates
+	// that are not limited by an Inventory.
+	ErrUnlimitedPlates = errors.New("plates are not limited by inventory")
+	// ErrInvalidThicknessPlates is returned when a Thicknesses entry does not
+	// match any of the plate Weights, or is not more than zero and at most MaxThickness.
+	ErrInvalidThicknessPlates = errors.New("invalid thickness: plates")
 )
 
+// MaxThickness is the thickest a plate can be in millimeters, well past the thickness
+// of any real plate.
+const MaxThickness = 200.0
+
 // DefaultWeightsKB is the default set of weights in KB
-var DefaultWeightsKB = []float64{
-	1.25,
-	2.5,
-	5,
-	10,
-	15,
-	20,
-}
+var DefaultWeightsKB = weights(1.25, 2.5, 5, 10, 15, 20)
 
 // DefaultWeightsLBS is the default set of weights in LBS
-var DefaultWeightsLBS = []float64{
-	2.5,
-	5,
-	10,
-	25,
-	35,
-	45,
-}
+var DefaultWeightsLBS = weights(2.5, 5, 10, 25, 35, 45)
 
 // DefaultPlatesLBS is the default set of plates in LBS
 var DefaultPlatesLBS = Plates{
@@ -52,26 +70,127 @@ var DefaultPlatesKG = Plates{
 }
 
 func (p Plates) String() string {
-	return fmt.Sprintf("Weights: %v, Unit: %v", p.Weights, p.Unit)
+	s := fmt.Sprintf("Weights: %v, Unit: %v", p.Weights, p.Unit)
+	if len(p.Inventory) > 0 {
+		s += fmt.Sprintf(", Inventory: %v", p.Inventory)
+	}
+	if len(p.Thicknesses) > 0 {
+		s += fmt.Sprintf(", Thicknesses: %v", p.Thicknesses)
+	}
+	return s
+}
+
+// String prints the human readable format of a Plate.
+func (p Plate) String() string {
+	return fmt.Sprintf("%vx%v", p.Count, p.Weight)
+}
+
+// String prints the human readable format of a Thickness.
+func (t Thickness) String() string {
+	return fmt.Sprintf("%v:%vmm", t.Weight, t.Thickness)
 }
 
 // Tidy cleans up the plates by removing duplicates and sorting them
 func (p *Plates) Tidy() {
 	p.Weights = tidy(p.Weights)
+	p.Inventory = tidyInventory(p.Inventory)
+	p.Thicknesses = tidyThicknesses(p.Thicknesses)
+}
+
+// SetCount limits the number of plates owned for a weight, adding the weight
+// to the set of plates if needed. A count of zero removes the limit.
+func (p *Plates) SetCount(plate Weight, count uint) {
+	p.Weights = addItem(p.Weights, plate)
+	var inv []Plate
+	for _, i := range p.Inventory {
+		if i.Weight != plate {
+			inv = append(inv, i)
+		}
+	}
+	if count > 0 {
+		inv = append(inv, Plate{Weight: plate, Count: count})
+	}
+	p.Inventory = tidyInventory(inv)
+}
+
+// Count returns the number of plates owned for a weight. The boolean is false
+// when the weight is not limited by the Inventory.
+func (p Plates) Count(plate Weight) (uint, bool) {
+	for _, i := range p.Inventory {
+		if i.Weight == plate {
+			return i.Count, true
+		}
+	}
+	return 0, false
+}
+
+// SetThickness sets how thick a plate of a weight is in millimeters, adding the
+// weight to the set of plates if needed. A thickness of zero removes it.
+func (p *Plates) SetThickness(plate Weight, thickness float64) {
+	p.Weights = addItem(p.Weights, plate)
+	var th []Thickness
+	for _, t := range p.Thicknesses {
+		if t.Weight != plate {
+			th = append(th, t)
+		}
+	}
+	if thickness > 0 {
+		th = append(th, Thickness{Weight: plate, Thickness: thickness})
+	}
+	p.Thicknesses = tidyThicknesses(th)
+}
+
+// Thickness returns how thick a plate of a weight is in millimeters. The boolean
+// is false when the thickness is unknown.
+func (p Plates) Thickness(plate Weight) (float64, bool) {
+	for _, t := range p.Thicknesses {
+		if t.Weight == plate {
+			return t.Thickness, true
+		}
+	}
+	return 0, false
+}
+
+// Limited returns true if every plate weight has a count in the Inventory,
+// meaning there is a maximum weight that can be loaded.
+func (p Plates) Limited() bool {
+	if len(p.Weights) == 0 {
+		return false
+	}
+	for _, w := range p.Weights {
+		if _, ok := p.Count(w); !ok {
+			return false
+		}
+	}
+	return true
 }
 
 // Add takes a plate and adds it to the set of weights.
-func (p *Plates) Add(plate float64) {
+func (p *Plates) Add(plate Weight) {
 	p.Weights = addItem(p.Weights, plate)
 }
 
 // Remove takes a plate and removes it from the set of weights.
-func (p *Plates) Remove(plate float64) {
+func (p *Plates) Remove(plate Weight) {
 	p.Weights = removeItem(p.Weights, plate)
+	var inv []Plate
+	for _, i := range p.Inventory {
+		if i.Weight != plate {
+			inv = append(inv, i)
+		}
+	}
+	p.Inventory = tidyInventory(inv)
+	var th []Thickness
+	for _, t := range p.Thicknesses {
+		if t.Weight != plate {
+			th = append(th, t)
+		}
+	}
+	p.Thicknesses = tidyThicknesses(th)
 }
 
 // Min gets the smallest increment of plate in the Weights slice.
-func (p Plates) Min() (float64, error) {
+func (p Plates) Min() (Weight, error) {
 	p.Tidy()
 	if err := p.Valid(); err != nil {
 		return 0, err
@@ -82,23 +201,46 @@ func (p Plates) Min() (float64, error) {
 // Equals compares all values in Weights and Unit and returns true
 // if all values are equal.
 func (p Plates) Equals(c Plates) bool {
-	return (p.Unit == c.Unit) && equal(p.Weights, c.Weights)
+	return (p.Unit == c.Unit) && equal(p.Weights, c.Weights) && equalInventory(p.Inventory, c.Inventory) &&
+		equalThicknesses(p.Thicknesses, c.Thicknesses)
 }
 
-// Round takes a weight and uses its increment for rounding and doubles the increment
-// to match the smallest increment per side of the bar. It returns the closest number
-// below or even to the weight that is divisible by the doubled increment
-func (p Plates) Round(weight float64) (float64, error) {
-	m, err := p.Min()
+// Round takes a weight and returns the heaviest total, loaded evenly on both sides
+// of the bar, at or below the weight that can be built from the plates. Plates
+// don't need to be multiples of the smallest plate, for example {1.25, 2, 5} can
+// build 6.5 from 2 + 1.25 on each side.
+func (p Plates) Round(weight Weight) (Weight, error) {
+	if err := p.Valid(); err != nil {
+		return 0, err
+	}
+	sol, err := solve(weight, p.stock(), 0, FewestPlates, nil)
 	if err != nil {
 		return 0, err
 	}
-	b := m * 2
-	return float64(int(weight/b)) * b, nil
+	return sum(sol.below) * 2, nil
 }
 
+// Totals returns every total, loaded evenly on both sides of the bar, up to and
+// including max that can be built from the plates. Totals are sorted lightest
+// first and always start with 0 for an empty bar.
+func (p Plates) Totals(max Weight) ([]Weight, error) {
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	return reachable(max, p.stock())
+}
+
+// PlateResolution is the smallest step between plate weights, every plate weight is a
+// multiple of it, such as 0.125 for 1/8 of a pound. It keeps the tables of the plate
+// solver small.
+const PlateResolution Weight = Precision / 200
+
+// stockResolution is the step that plates converted from another unit are snapped to,
+// a twentieth of the unit of the Gear, such as 0.55 lbs for a 0.25 kg change plate.
+const stockResolution Weight = Precision / 20
+
 // Valid checks that Unit is Valid and checks that length > 0
-// and that all plates greater than zero
+// and that all plates are greater than zero and a multiple of the PlateResolution
 func (p Plates) Valid() error {
 	if !p.Unit.Valid() {
 		return ErrInvalidUnitPlates
@@ -107,15 +249,39 @@ func (p Plates) Valid() error {
 		return ErrNoPlatesFound
 	}
 	for _, w := range p.Weights {
-		if w <= 0 {
+		if w <= 0 || w%PlateResolution != 0 {
 			return ErrInvalidWeightsPlates
 		}
 	}
+	for _, i := range p.Inventory {
+		if !contains(p.Weights, i.Weight) {
+			return ErrInvalidInventoryPlates
+		}
+	}
+	for _, t := range p.Thicknesses {
+		if !contains(p.Weights, t.Weight) || !(t.Thickness > 0) || t.Thickness > MaxThickness {
+			return ErrInvalidThicknessPlates
+		}
+	}
 	return nil
 }
 
-// equal compares to lists of floats and returns a boolean value on equality.
-func equal(a, b []float64) bool {
+// equal compares two lists of weights and returns a boolean value on equality.
+func equal(a, b []Weight) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalInventory compares two inventories and returns a boolean value on equality.
+func equalInventory(a, b []Plate) bool {
+	a, b = tidyInventory(a), tidyInventory(b)
 	if len(a) != len(b) {
 		return false
 	}
@@ -127,31 +293,94 @@ func equal(a, b []float64) bool {
 	return true
 }
 
+// equalThicknesses compares two sets of thicknesses and returns a boolean value on equality.
+func equalThicknesses(a, b []Thickness) bool {
+	a, b = tidyThicknesses(a), tidyThicknesses(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(slice []Weight, item Weight) bool {
+	for _, x := range slice {
+		if x == item {
+			return true
+		}
+	}
+	return false
+}
+
 // TODO: move to sets = https://github.com/deckarep/golang-set
 
-// tidy takes a slice of float64, removes any duplicate values and sorts the output.
-func tidy(input []float64) []float64 {
-	m := make(map[float64]struct{})
+// tidy takes a slice of Weight, removes any duplicate values and sorts the output.
+func tidy(input []Weight) []Weight {
+	m := make(map[Weight]struct{})
 	for _, x := range input {
 		if x > 0 { // don't allow 0 or negative value plates
 			m[x] = struct{}{}
 		}
 	}
-	o := make([]float64, len(m))
+	o := make([]Weight, len(m))
 	i := 0
 	for k := range m {
 		o[i] = k
 		i++
 	}
-	sort.Float64s(o)
+	sort.Slice(o, func(i, j int) bool { return o[i] < o[j] })
+	return o
+}
+
+// tidyInventory removes empty and duplicate entries, keeping the last count
+// seen for a weight, and sorts the output by weight.
+func tidyInventory(input []Plate) []Plate {
+	m := make(map[Weight]uint)
+	for _, i := range input {
+		if i.Weight > 0 && i.Count > 0 {
+			m[i.Weight] = i.Count
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	o := make([]Plate, 0, len(m))
+	for w, c := range m {
+		o = append(o, Plate{Weight: w, Count: c})
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].Weight < o[j].Weight })
+	return o
+}
+
+// tidyThicknesses removes empty and duplicate entries, keeping the last thickness
+// seen for a weight, and sorts the output by weight.
+func tidyThicknesses(input []Thickness) []Thickness {
+	m := make(map[Weight]float64)
+	for _, t := range input {
+		if t.Weight > 0 && t.Thickness > 0 {
+			m[t.Weight] = t.Thickness
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	o := make([]Thickness, 0, len(m))
+	for w, t := range m {
+		o = append(o, Thickness{Weight: w, Thickness: t})
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].Weight < o[j].Weight })
 	return o
 }
 
-func addItem(slice []float64, item float64) []float64 {
+func addItem(slice []Weight, item Weight) []Weight {
 	return tidy(append(slice, item))
 }
 
-func removeItem(slice []float64, item float64) (output []float64) {
+func removeItem(slice []Weight, item Weight) (output []Weight) {
 	slice = tidy(slice)
 	for _, x := range slice {
 		if x != item {
@@ -163,19 +392,38 @@ func removeItem(slice []float64, item float64) (output []float64) {
 
 // Recommend takes a weight and a set of plates and returns
 // a sorted recommendation of plates for one side of the bar
-func Recommend(weight float64, plates []float64) ([]float64, error) {
-	var rec []float64
-	if len(plates) == 0 {
-		return nil, ErrNoPlatesFound
+func Recommend(weight Weight, plates []Weight) ([]Weight, error) {
+	return Plates{Weights: plates}.recommend(weight, FewestPlates, nil)
+}
+
+// Recommend takes a weight and returns a sorted recommendation of plates for
+// one side of the bar, never using more plates than the Inventory holds.
+func (p Plates) Recommend(weight Weight) ([]Weight, error) {
+	if err := p.Valid(); err != nil {
+		return nil, err
 	}
-	sort.Float64s(plates)
-	for i := len(plates) - 1; i >= 0; i-- {
-		n := int(weight / (plates[i] * 2))
-		for j := 1; j <= n; j++ {
-			rec = append(rec, plates[i])
-		}
-		weight = weight - (float64(n) * (plates[i] * 2))
+	return p.recommend(weight, FewestPlates, nil)
+}
+
+// recommend solves for the plates of one side of the bar from the weight
+// of plates on both sides.
+func (p Plates) recommend(weight Weight, policy Policy, previous []Weight) ([]Weight, error) {
+	sol, err := solve(weight, p.stock(), 0, policy, previous)
+	return sol.below, err
+}
+
+// Max returns the total weight of every plate that can be loaded in pairs.
+// It returns ErrUnlimitedPlates when any weight is not limited by the Inventory.
+func (p Plates) Max() (Weight, error) {
+	if err := p.Valid(); err != nil {
+		return 0, err
+	}
+	if !p.Limited() {
+		return 0, ErrUnlimitedPlates
+	}
+	var total Weight
+	for _, i := range p.Inventory {
+		total += Weight(i.Count/2) * i.Weight * 2
 	}
-	sort.Float64s(rec)
-	return rec, nil
+	return total, nil
 }
@@ -8,11 +8,11 @@ import (
 func TestTidy(t *testing.T) {
 	t.Parallel()
 	tt := []struct {
-		input    []float64
-		expected []float64
+		input    []Weight
+		expected []Weight
 	}{
-		{[]float64{1, 1, 1, 1, 1}, []float64{1}},
-		{[]float64{70, 50, 90, 90, 0}, []float64{50, 70, 90}},
+		{weights(1, 1, 1, 1, 1), weights(1)},
+		{weights(70, 50, 90, 90, 0), weights(50, 70, 90)},
 	}
 	for _, test := range tt {
 		if o := tidy(test.input); !equal(o, test.expected) {
@@ -24,15 +24,15 @@ func TestTidy(t *testing.T) {
 func TestAddItem(t *testing.T) {
 	t.Parallel()
 	tt := []struct {
-		slice    []float64
+		slice    []Weight
 		item     float64
-		expected []float64
+		expected []Weight
 	}{
-		{[]float64{}, 5, []float64{5}},
+		{[]Weight{}, 5, weights(5)},
 	}
 
 	for _, test := range tt {
-		if o := addItem(test.slice, test.item); !equal(o, test.expected) {
+		if o := addItem(test.slice, NewWeight(test.item)); !equal(o, test.expected) {
 			t.Error("unexpected mismatch:", o, test.expected)
 		}
 	}
@@ -41,17 +41,17 @@ func TestAddItem(t *testing.T) {
 func TestRemoveItem(t *testing.T) {
 	t.Parallel()
 	tt := []struct {
-		slice    []float64
+		slice    []Weight
 		item     float64
-		expected []float64
+		expected []Weight
 	}{
-		{[]float64{}, 5, []float64{}},
-		{[]float64{5, 5, 5}, 5, []float64{}},
-		{[]float64{1, 2, 3, 4, 5}, 5, []float64{1, 2, 3, 4}},
+		{[]Weight{}, 5, []Weight{}},
+		{weights(5, 5, 5), 5, []Weight{}},
+		{weights(1, 2, 3, 4, 5), 5, weights(1, 2, 3, 4)},
 	}
 
 	for _, test := range tt {
-		if o := removeItem(test.slice, test.item); !equal(o, test.expected) {
+		if o := removeItem(test.slice, NewWeight(test.item)); !equal(o, test.expected) {
 			t.Error("unexpected mismatch:", o, test.expected)
 		}
 	}
@@ -61,19 +61,20 @@ func TestRecommend(t *testing.T) {
 	t.Parallel()
 	tt := []struct {
 		weight   float64
-		plates   []float64
-		expected []float64
+		plates   []Weight
+		expected []Weight
 		err      error
 	}{
-		{112.5, DefaultWeightsLBS, []float64{10, 45}, nil},
-		{387.5, DefaultWeightsLBS, []float64{2.5, 10, 45, 45, 45, 45}, nil},
-		{0, DefaultWeightsLBS, []float64{}, nil},
-		{270, DefaultWeightsLBS, []float64{45, 45, 45}, nil},
-		{271, DefaultWeightsLBS, []float64{45, 45, 45}, nil},
-		{0, []float64{}, []float64{}, ErrNoPlatesFound},
+		{112.5, DefaultWeightsLBS, weights(10, 45), nil},
+		{387.5, DefaultWeightsLBS, weights(2.5, 10, 45, 45, 45, 45), nil},
+		{0, DefaultWeightsLBS, []Weight{}, nil},
+		{270, DefaultWeightsLBS, weights(45, 45, 45), nil},
+		{271, DefaultWeightsLBS, weights(45, 45, 45), nil},
+		{70, weights(1.25, 15, 20, 25), weights(15, 20), nil},
+		{0, []Weight{}, []Weight{}, ErrNoPlatesFound},
 	}
 	for _, test := range tt {
-		r, err := Recommend(test.weight, test.plates)
+		r, err := Recommend(NewWeight(test.weight), test.plates)
 		if err != test.err {
 			t.Error("unexpected error:", err, test.err)
 		}
@@ -104,10 +105,10 @@ func TestPlates(t *testing.T) {
 		t.Parallel()
 		tt := []struct {
 			input    Plates
-			expected []float64
+			expected []Weight
 		}{
-			{Plates{Weights: []float64{5, 5, 5, 5, 5}, Unit: KG}, []float64{5}},
-			{Plates{Weights: []float64{5, 4, 3, 2, 1, 0, 0}, Unit: LBS}, []float64{1, 2, 3, 4, 5}},
+			{Plates{Weights: weights(5, 5, 5, 5, 5), Unit: KG}, weights(5)},
+			{Plates{Weights: weights(5, 4, 3, 2, 1, 0, 0), Unit: LBS}, weights(1, 2, 3, 4, 5)},
 		}
 		for _, test := range tt {
 			test.input.Tidy()
@@ -123,9 +124,9 @@ func TestPlates(t *testing.T) {
 			expected float64
 			err      error
 		}{
-			{Plates{Weights: []float64{5, 10, 15, 20, 25}, Unit: KG}, 5, nil},
-			{Plates{Weights: []float64{55, 10, 15, 20, 25}, Unit: KG}, 10, nil},
-			{Plates{Weights: []float64{-1, 10, 15, 20, 25}, Unit: Unit(5)}, 10, ErrInvalidUnitPlates},
+			{Plates{Weights: weights(5, 10, 15, 20, 25), Unit: KG}, 5, nil},
+			{Plates{Weights: weights(55, 10, 15, 20, 25), Unit: KG}, 10, nil},
+			{Plates{Weights: weights(-1, 10, 15, 20, 25), Unit: Unit(5)}, 10, ErrInvalidUnitPlates},
 		}
 		for i, test := range tt {
 			o, err := test.input.Min()
@@ -133,7 +134,7 @@ func TestPlates(t *testing.T) {
 				if !errors.Is(err, test.err) {
 					t.Error("error mismatch for test", i, test.err, ErrInvalidUnitPlates)
 				}
-			} else if o != test.expected {
+			} else if o != NewWeight(test.expected) {
 				t.Error("unexpected result: ", i, o, test.expected)
 			}
 		}
@@ -146,16 +147,146 @@ func TestPlates(t *testing.T) {
 			expected float64
 			err      error
 		}{
-			{Plates{Weights: []float64{5, 10, 15, 20, 25}, Unit: KG}, 333, 330, nil},
-			{Plates{Weights: []float64{-1, 10, 15, 20, 25}, Unit: Unit(5)}, 0, 0, ErrInvalidUnitPlates},
+			{Plates{Weights: weights(5, 10, 15, 20, 25), Unit: KG}, 333, 330, nil},
+			{Plates{Weights: weights(1.25, 2, 5, 10, 25), Unit: KG}, 7, 6.5, nil},
+			{Plates{Weights: weights(1.25, 2, 5, 10, 25), Unit: KG}, 9, 9, nil},
+			{Plates{Weights: weights(2, 5), Unit: LBS}, 10, 10, nil},
+			{Plates{Weights: weights(2, 5), Unit: LBS}, 3, 0, nil},
+			{Plates{Weights: weights(-1, 10), Unit: KG}, 0, 0, ErrInvalidWeightsPlates},
+			{Plates{Weights: weights(0.001, 0.003, 45), Unit: LBS}, 0, 0, ErrInvalidWeightsPlates},
+			{Plates{Weights: weights(0.125, 45), Unit: LBS}, 90.25, 90.25, nil},
+			{Plates{Weights: weights(-1, 10, 15, 20, 25), Unit: Unit(5)}, 0, 0, ErrInvalidUnitPlates},
 		}
 		for i, test := range tt {
-			o, err := test.plates.Round(test.input)
+			o, err := test.plates.Round(NewWeight(test.input))
 			if err != nil {
 				if !errors.Is(err, test.err) {
 					t.Error("error mismatch for test", i, test.err, ErrInvalidUnitPlates)
 				}
-			} else if o != test.expected {
+			} else if o != NewWeight(test.expected) {
+				t.Error("unexpected result: ", i, o, test.expected)
+			}
+		}
+	})
+	t.Run("Inventory", func(t *testing.T) {
+		t.Parallel()
+		p := Plates{Weights: weights(10, 25), Unit: LBS}
+		p.SetCount(45*Precision, 2)
+		p.SetCount(25*Precision, 3)
+		if !equal(p.Weights, weights(10, 25, 45)) {
+			t.Error("SetCount did not add weight:", p.Weights)
+		}
+		if c, ok := p.Count(45 * Precision); !ok || c != 2 {
+			t.Error("unexpected count for 45:", c, ok)
+		}
+		if _, ok := p.Count(10 * Precision); ok {
+			t.Error("expected 10 to be unlimited")
+		}
+		if p.Limited() {
+			t.Error("expected plates to not be limited")
+		}
+		if _, err := p.Max(); err != ErrUnlimitedPlates {
+			t.Error("unexpected error:", err)
+		}
+		if s := p.String(); s != "Weights: [10 25 45], Unit: LBS, Inventory: [3x25 2x45]" {
+			t.Error("unexpected string:", s)
+		}
+		p.SetCount(10*Precision, 4)
+		if max, err := p.Max(); err != nil || max != 180*Precision {
+			t.Error("unexpected max:", max, err)
+		}
+		p.SetCount(10*Precision, 0)
+		if _, ok := p.Count(10 * Precision); ok {
+			t.Error("expected count of 0 to remove the limit")
+		}
+		p.Remove(45 * Precision)
+		if _, ok := p.Count(45 * Precision); ok || contains(p.Weights, 45*Precision) {
+			t.Error("expected 45 to be removed:", p)
+		}
+	})
+	t.Run("Thickness", func(t *testing.T) {
+		t.Parallel()
+		p := Plates{Weights: weights(10, 25), Unit: LBS}
+		p.SetThickness(45*Precision, 40)
+		p.SetThickness(25*Precision, 30)
+		if !equal(p.Weights, weights(10, 25, 45)) {
+			t.Error("SetThickness did not add weight:", p.Weights)
+		}
+		if th, ok := p.Thickness(45 * Precision); !ok || th != 40 {
+			t.Error("unexpected thickness for 45:", th, ok)
+		}
+		if _, ok := p.Thickness(10 * Precision); ok {
+			t.Error("expected 10 to be unknown")
+		}
+		if s := p.String(); s != "Weights: [10 25 45], Unit: LBS, Thicknesses: [25:30mm 45:40mm]" {
+			t.Error("unexpected string:", s)
+		}
+		if c := (Plates{Weights: p.Weights, Unit: LBS}); c.Equals(p) {
+			t.Error("expected thicknesses to be compared")
+		}
+		bad := p
+		bad.Thicknesses = append([]Thickness{{Weight: 100 * Precision, Thickness: 50}}, p.Thicknesses...)
+		if err := bad.Valid(); err != ErrInvalidThicknessPlates {
+			t.Error("unexpected error:", err)
+		}
+		p.SetThickness(25*Precision, 0)
+		if _, ok := p.Thickness(25 * Precision); ok {
+			t.Error("expected thickness of 0 to remove it")
+		}
+		p.Remove(45 * Precision)
+		if _, ok := p.Thickness(45 * Precision); ok || contains(p.Weights, 45*Precision) {
+			t.Error("expected 45 to be removed:", p)
+		}
+	})
+	t.Run("Recommend", func(t *testing.T) {
+		t.Parallel()
+		p := Plates{Weights: weights(10, 25, 45), Unit: LBS}
+		p.SetCount(45*Precision, 2)
+		p.SetCount(25*Precision, 2)
+		p.SetCount(10*Precision, 4)
+		tt := []struct {
+			plates   Plates
+			input    float64
+			expected []Weight
+			err      error
+		}{
+			{p, 0, []Weight{}, nil},
+			{p, 155, weights(25, 45), nil},
+			{p, 270, weights(10, 10, 25, 45), nil},
+			{Plates{Weights: weights(45), Unit: LBS}, 270, weights(45, 45, 45), nil},
+			{Plates{Weights: weights(45), Unit: Unit(5)}, 270, []Weight{}, ErrInvalidUnitPlates},
+			{Plates{Weights: weights(45), Unit: LBS, Inventory: []Plate{{Weight: 5 * Precision, Count: 2}}}, 270, []Weight{}, ErrInvalidInventoryPlates},
+		}
+		for i, test := range tt {
+			o, err := test.plates.Recommend(NewWeight(test.input))
+			if err != test.err {
+				t.Error("error mismatch for test", i, err, test.err)
+			} else if !equal(o, test.expected) {
+				t.Error("unexpected result: ", i, o, test.expected)
+			}
+		}
+	})
+	t.Run("Totals", func(t *testing.T) {
+		t.Parallel()
+		limited := Plates{Weights: weights(2, 5), Unit: LBS}
+		limited.SetCount(5*Precision, 2)
+		limited.SetCount(2*Precision, 2)
+		tt := []struct {
+			plates   Plates
+			max      float64
+			expected []Weight
+			err      error
+		}{
+			{Plates{Weights: weights(1.25, 2), Unit: KG}, 10, weights(0, 2.5, 4, 5, 6.5, 7.5, 8, 9, 10), nil},
+			{limited, 100, weights(0, 4, 10, 14), nil},
+			{Plates{Weights: weights(2), Unit: KG}, -1, nil, nil},
+			{Plates{Unit: KG}, 10, nil, ErrNoPlatesFound},
+		}
+		for i, test := range tt {
+			o, err := test.plates.Totals(NewWeight(test.max))
+			if err != test.err {
+				t.Error("error mismatch for test", i, err, test.err)
+			} else if !equal(o, test.expected) {
 				t.Error("unexpected result: ", i, o, test.expected)
 			}
 		}
@@ -166,10 +297,12 @@ func TestPlates(t *testing.T) {
 		badUnit := DefaultPlatesKG
 		badUnit.Unit = LBS
 		badLen := DefaultPlatesKG
-		badLen.Add(200)
+		badLen.Add(200 * Precision)
 		badWeights := DefaultPlatesKG
-		badWeights.Add(200)
-		badWeights.Remove(5)
+		badWeights.Add(200 * Precision)
+		badWeights.Remove(5 * Precision)
+		badInventory := DefaultPlatesKG
+		badInventory.SetCount(20*Precision, 2)
 
 		tt := []struct {
 			plates   Plates
@@ -181,6 +314,7 @@ func TestPlates(t *testing.T) {
 			{DefaultPlatesKG, badUnit, false},
 			{DefaultPlatesKG, badLen, false},
 			{DefaultPlatesKG, badWeights, false},
+			{DefaultPlatesKG, badInventory, false},
 		}
 		for _, test := range tt {
 			if o := test.plates.Equals(test.comp); o != test.expected {
@@ -0,0 +1,105 @@
+package gear
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidNameProfile is returned for a Profile without a name.
+	ErrInvalidNameProfile = errors.New("invalid name: profile")
+	// ErrProfileNotFound is returned when a name is not in the Profiles catalog.
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+var (
+	// HomeProfile represents a home gym with a men's bar and a limited set of LBS plates.
+	HomeProfile = Profile{
+		Name: "home",
+		Gear: Gear{
+			Bar: MensBarLBS,
+			Plates: Plates{
+				Weights: weights(2.5, 5, 10, 25, 45),
+				Unit:    LBS,
+				Inventory: []Plate{
+					{Weight: NewWeight(2.5), Count: 2},
+					{Weight: 5 * Precision, Count: 2},
+					{Weight: 10 * Precision, Count: 2},
+					{Weight: 25 * Precision, Count: 2},
+					{Weight: 45 * Precision, Count: 4},
+				},
+			},
+			Unit: LBS,
+		},
+	}
+	// CompetitionKGProfile represents a competition platform with a men's bar, change
+	// plates down to 0.5 KG and competition collars.
+	CompetitionKGProfile = Profile{
+		Name: "competition-kg",
+		Gear: Gear{
+			Bar:         MensBarKG,
+			Plates:      Plates{Weights: weights(0.5, 1, 1.25, 2.5, 5, 10, 15, 20, 25), Unit: KG},
+			Attachments: []Attachment{CompetitionCollarsKG},
+			Unit:        KG,
+		},
+	}
+	// GloboGymProfile represents a commercial gym with plenty of LBS plates, rounded
+	// to the nearest weight.
+	GloboGymProfile = Profile{
+		Name: "globo-gym",
+		Gear: Gear{
+			Bar:      MensBarLBS,
+			Plates:   Plates{Weights: weights(2.5, 5, 10, 25, 35, 45), Unit: LBS},
+			Unit:     LBS,
+			Rounding: Nearest,
+		},
+	}
+)
+
+// Profiles is the catalog of named gear profiles, see ProfileFromName.
+var Profiles = []Profile{
+	HomeProfile,
+	CompetitionKGProfile,
+	GloboGymProfile,
+}
+
+// Profile is a named set of Gear, such as the gear at one gym, so the gear can be
+// picked by name instead of spelling out every plate.
+type Profile struct {
+	Name string `json:"name"`
+	Gear Gear   `json:"gear"`
+}
+
+// ProfileFromName takes the Name of a profile and returns the profile from the
+// Profiles catalog or ErrProfileNotFound.
+func ProfileFromName(name string) (Profile, error) {
+	for _, p := range Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Profile{}, ErrProfileNotFound
+}
+
+// profileFromGear returns the profile from the Profiles catalog with the same Gear.
+func profileFromGear(g Gear) (Profile, error) {
+	for _, p := range Profiles {
+		if p.Gear.Equals(g) {
+			return p, nil
+		}
+	}
+	return Profile{}, ErrProfileNotFound
+}
+
+// String prints the human readable string format of the profile.
+func (p Profile) String() string {
+	return fmt.Sprintf("Name: %v, Gear: { %v }", p.Name, p.Gear)
+}
+
+// Valid checks that the profile has a Name and valid Gear.
+func (p Profile) Valid() error {
+	if p.Name == "" {
+		return ErrInvalidNameProfile
+	}
+	return p.Gear.Valid()
+}
@@ -0,0 +1,74 @@
+package gear
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		p := Profile{Name: "garage", Gear: Default(KG)}
+		if s := p.String(); s != "Name: garage, Gear: { Unit: KG, Bar: { Name: mens-kg, Weight: 20, Unit: KG, Sleeve: 415mm }, Plates: { Weights: [1.25 2.5 5 10 15 20], Unit: KG } }" {
+			t.Error("unexpected string:", s)
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input Profile
+			err   error
+		}{
+			{HomeProfile, nil},
+			{CompetitionKGProfile, nil},
+			{GloboGymProfile, nil},
+			{Profile{Gear: Default(LBS)}, ErrInvalidNameProfile},
+			{Profile{Name: "empty"}, ErrInvalidWeightBar},
+		}
+		for _, test := range tt {
+			if err := test.input.Valid(); err != test.err {
+				t.Error("unexpected error:", test.input.Name, err, test.err)
+			}
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		for _, p := range Profiles {
+			b, err := json.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var o Profile
+			if err := json.Unmarshal(b, &o); err != nil {
+				t.Error("unexpected error:", p.Name, err)
+			} else if o.Name != p.Name || !o.Gear.Equals(p.Gear) {
+				t.Error("unexpected profile:", o, p)
+			}
+		}
+	})
+}
+
+func TestProfileFromName(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name     string
+		expected Profile
+		err      error
+	}{
+		{"home", HomeProfile, nil},
+		{"competition-kg", CompetitionKGProfile, nil},
+		{"globo-gym", GloboGymProfile, nil},
+		{"moon base", Profile{}, ErrProfileNotFound},
+		{"", Profile{}, ErrProfileNotFound},
+	}
+	for _, test := range tt {
+		o, err := ProfileFromName(test.name)
+		if err != test.err {
+			t.Error("unexpected error:", test.name, err, test.err)
+		}
+		if o.Name != test.expected.Name || !o.Gear.Equals(test.expected.Gear) {
+			t.Error("unexpected profile:", test.name, o, test.expected)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidRounding represents an invalid Rounding
+	ErrInvalidRounding = errors.New("invalid rounding")
+)
+
+// Rounding is used to enumerate the ways a weight is rounded to a weight that can be
+// built with the Gear.
+type Rounding uint
+
+const (
+	// Floor rounds down to the heaviest weight at or under the requested weight.
+	Floor Rounding = iota
+	// Nearest rounds to the closest weight, favoring the lighter weight on a tie.
+	Nearest
+	// Ceiling rounds up to the lightest weight at or over the requested weight.
+	Ceiling
+	// Capped rounds to the closest weight, but never above the Tolerance.
+	Capped
+)
+
+var stringToRounding = map[string]Rounding{
+	"floor":   Floor,
+	"nearest": Nearest,
+	"ceiling": Ceiling,
+	"capped":  Capped,
+}
+
+// String prints the human readable value from the enum
+func (r Rounding) String() string {
+	n := []string{"floor", "nearest", "ceiling", "capped"}
+	if int(r) < len(n) {
+		return n[r]
+	}
+	return ""
+}
+
+// Valid checks that a Rounding is one of the enumerated values.
+func (r Rounding) Valid() bool {
+	return int(r) < len(stringToRounding)
+}
+
+// RoundingFromString takes a string and returns a Rounding or an error
+func RoundingFromString(s string) (Rounding, error) {
+	rounding, ok := stringToRounding[s]
+	if !ok {
+		return 0, ErrInvalidRounding
+	}
+	return rounding, nil
+}
+
+// MarshalJSON is used for human readable json.
+func (r Rounding) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, r)), nil
+}
+
+// UnmarshalJSON is used to convert human readable json to a Rounding type
+func (r *Rounding) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	rounding, err := RoundingFromString(s)
+	if err != nil {
+		return err
+	}
+	*r = rounding
+	return nil
+}
+
+// up returns true when a requested weight should be rounded up to the weight above,
+// rather than down to the weight below. Limit is the heaviest weight allowed when
+// Capped, and over is false when there is no weight above to round up to.
+func (r Rounding) up(weight, below, above, limit Weight, over bool) bool {
+	if !over {
+		return false
+	}
+	switch r {
+	case Ceiling:
+		return true
+	case Nearest:
+		return above-weight < weight-below
+	case Capped:
+		return above-weight < weight-below && above <= limit
+	default:
+		return false
+	}
+}
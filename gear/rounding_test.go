@@ -0,0 +1,58 @@
+package gear
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRounding(t *testing.T) {
+	t.Parallel()
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Rounding
+			expected string
+		}{
+			{Floor, "floor"},
+			{Nearest, "nearest"},
+			{Ceiling, "ceiling"},
+			{Capped, "capped"},
+			{Rounding(9), ""},
+		}
+		for _, test := range tt {
+			if test.input.String() != test.expected {
+				t.Error("match failed for", test)
+			}
+		}
+	})
+	t.Run("MarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		o, _ := Capped.MarshalJSON()
+		if !bytes.Equal(o, []byte(`"capped"`)) {
+			t.Error("unexpected json:", string(o))
+		}
+	})
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    []byte
+			expected Rounding
+			err      error
+		}{
+			{[]byte(`"nearest"`), Nearest, nil},
+			{[]byte(`"blah"`), Floor, ErrInvalidRounding},
+			{[]byte(`false`), Floor, errors.New("json: cannot unmarshal bool into Go value of type string")},
+		}
+		for _, test := range tt {
+			var r Rounding
+			if err := r.UnmarshalJSON(test.input); err != nil {
+				if err.Error() != test.err.Error() {
+					t.Error("got:", err, "expected:", test.err)
+				}
+			} else if r != test.expected {
+				t.Error("test failed for:", test)
+			}
+		}
+	})
+}
@@ -0,0 +1,443 @@
+package gear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// thicknessResolution is the number of steps per millimeter used when fitting
+	// plates on a sleeve, plate thickness is solved in tenths of a millimeter.
+	thicknessResolution = 10
+	// maxSolverStates is the largest table the solver will build before giving up,
+	// about 8 MB, and twice that when plates have to fit on the sleeve.
+	maxSolverStates = 1 << 20
+	// unreachable is the cost of a plate total that can't be built.
+	unreachable = math.MaxInt
+	// changeCost weighs a plate change above any number of plates so that
+	// FewestChanges always prefers fewer changes, then fewer plates.
+	changeCost = 1 << 20
+)
+
+var (
+	// ErrInvalidPolicy represents an invalid Policy
+	ErrInvalidPolicy = errors.New("invalid policy")
+	// ErrWeightOutOfRange is returned when a weight is too large to solve for.
+	ErrWeightOutOfRange = errors.New("weight out of range")
+)
+
+// Policy is used to enumerate the tie-break rules used when more than one
+// combination of plates loads the same weight.
+type Policy uint
+
+const (
+	// FewestPlates prefers the combination with the fewest plates.
+	FewestPlates Policy = iota
+	// HeaviestFirst prefers the combination that uses the most of the heaviest plates.
+	HeaviestFirst
+	// FewestChanges prefers the combination that differs from the previous set of
+	// plates by the fewest plates of each weight. It doesn't know the order plates
+	// are stacked in, so it can take more Changes than the fewest.
+	FewestChanges
+)
+
+var stringToPolicy = map[string]Policy{
+	"fewest plates":  FewestPlates,
+	"heaviest first": HeaviestFirst,
+	"fewest changes": FewestChanges,
+}
+
+// String prints the human readable value from the enum
+func (p Policy) String() string {
+	n := []string{"fewest plates", "heaviest first", "fewest changes"}
+	if int(p) < len(n) {
+		return n[p]
+	}
+	return ""
+}
+
+// PolicyFromString takes a string and returns a Policy or an error
+func PolicyFromString(s string) (Policy, error) {
+	policy, ok := stringToPolicy[s]
+	if !ok {
+		return 0, ErrInvalidPolicy
+	}
+	return policy, nil
+}
+
+// MarshalJSON is used for human readable json.
+func (p Policy) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, p)), nil
+}
+
+// UnmarshalJSON is used to convert human readable json to a Policy type
+func (p *Policy) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	policy, err := PolicyFromString(s)
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+// Load is a total weight and the plates for one side of the bar used to reach it.
+type Load struct {
+	Weight Weight   `json:"weight"`
+	Plates []Weight `json:"plates"`
+}
+
+// stock is a plate weight and the number of pairs available. A negative
+// number of pairs is unlimited. Thickness is in millimeters, zero when unknown.
+type stock struct {
+	weight    Weight
+	pairs     int
+	thickness float64
+}
+
+// stock returns the plates as a slice of stock, sorted heaviest first.
+func (p Plates) stock() []stock {
+	weights := tidy(p.Weights)
+	s := make([]stock, len(weights))
+	for i, w := range weights {
+		s[len(weights)-1-i] = stock{weight: w, pairs: -1}
+		if c, ok := p.Count(w); ok {
+			s[len(weights)-1-i].pairs = int(c / 2)
+		}
+		if t, ok := p.Thickness(w); ok {
+			s[len(weights)-1-i].thickness = t
+		}
+	}
+	return s
+}
+
+// solution is the plates for one side of the bar that are closest to a target
+// from below and from above. Over is false when nothing at or above the target
+// can be built.
+type solution struct {
+	below []Weight
+	above []Weight
+	over  bool
+}
+
+// solve finds the combinations of plates for one side of the bar that load a total,
+// for both sides of the bar, closest to the target from below and above. Ties are
+// broken by the Policy, previous is only used by FewestChanges and holds the plates
+// of one side of the bar from the set before. When sleeve is more than zero, only plates that fit on a sleeve
+// of that many millimeters are used, and when the Policy's choice doesn't fit the
+// thinnest plates for the same weight are used instead. Plates are returned sorted
+// lightest first.
+func solve(target Weight, plates []stock, sleeve float64, policy Policy, previous []Weight) (solution, error) {
+	if len(plates) == 0 {
+		return solution{}, ErrNoPlatesFound
+	}
+	if int(policy) >= len(stringToPolicy) {
+		return solution{}, ErrInvalidPolicy
+	}
+	if target <= 0 {
+		return solution{over: true}, nil
+	}
+
+	// every plate is loaded as a pair, so solve in whole pairs and shrink the
+	// steps down by the greatest common divisor of the pairs to keep the table small.
+	units := make([]int, len(plates))
+	thick := make([]int, len(plates))
+	step := 0
+	for i, p := range plates {
+		if p.weight <= 0 {
+			return solution{}, ErrInvalidWeightsPlates
+		}
+		units[i] = int(p.weight) * 2
+		thick[i] = int(math.Round(p.thickness * thicknessResolution))
+		step = gcd(step, units[i])
+	}
+	if int64(target)/int64(step) > maxSolverStates {
+		return solution{}, ErrWeightOutOfRange
+	}
+	down := int(target) / step
+	up := (int(target) + step - 1) / step
+	limit, largest := 0, 0
+	for i := range units {
+		units[i] /= step
+		if units[i] > largest {
+			largest = units[i]
+		}
+		if plates[i].pairs < 0 {
+			limit = -1
+		} else if limit >= 0 {
+			limit += units[i] * plates[i].pairs
+		}
+	}
+	// with any unlimited plate, something can always be built within one of
+	// the largest plate above the target.
+	total := up + largest
+	if limit >= 0 && limit < total {
+		total = limit
+	}
+	if (total+1)*(len(plates)+1) > maxSolverStates {
+		return solution{}, ErrWeightOutOfRange
+	}
+
+	prev := make([]int, len(plates))
+	for _, w := range previous {
+		for i, p := range plates {
+			if p.weight == w {
+				prev[i]++
+			}
+		}
+	}
+	cost := func(i, c int) int {
+		switch policy {
+		case FewestPlates:
+			return c
+		case FewestChanges:
+			d := c - prev[i]
+			if d < 0 {
+				d = -d
+			}
+			return d*changeCost + c
+		default:
+			return 0
+		}
+	}
+	// every count from the previous count up costs the same again per plate.
+	slope := func(i int) (from, slope int) {
+		switch policy {
+		case FewestPlates:
+			return 0, 1
+		case FewestChanges:
+			return prev[i], changeCost + 1
+		default:
+			return 0, 0
+		}
+	}
+	maxCount := func(i, s int) int {
+		n := s / units[i]
+		if plates[i].pairs >= 0 && n > plates[i].pairs {
+			n = plates[i].pairs
+		}
+		return n
+	}
+
+	// table[i][s] is the lowest cost of reaching s steps using plates[i:].
+	build := func(cost func(i, c int) int, slope func(i int) (from, slope int)) [][]int {
+		table := make([][]int, len(plates)+1)
+		for i := range table {
+			table[i] = make([]int, total+1)
+		}
+		for s := 1; s <= total; s++ {
+			table[len(plates)][s] = unreachable
+		}
+		for i := len(plates) - 1; i >= 0; i-- {
+			from, m := slope(i)
+			fill(table[i], table[i+1], units[i], plates[i].pairs, from, m, func(c int) int { return cost(i, c) })
+		}
+		return table
+	}
+	table := build(cost, slope)
+
+	// thinnest[i][s] is the thinnest stack of plates[i:] that reaches s steps, and
+	// is only needed when the plates have to fit on the sleeve.
+	var thinnest [][]int
+	room := int(math.Floor(sleeve*thicknessResolution + 1e-6))
+	thickness := func(i, c int) int { return c * thick[i] }
+	if sleeve > 0 {
+		thinnest = build(thickness, func(i int) (int, int) { return 0, thick[i] })
+	}
+	fits := func(s int) bool {
+		return table[0][s] != unreachable && (thinnest == nil || thinnest[0][s] <= room)
+	}
+
+	// walk the table from the heaviest plate, always taking as many of
+	// a plate as the lowest cost allows. It also returns the thickness
+	// of the plates.
+	walk := func(table [][]int, cost func(i, c int) int, s int) ([]Weight, int) {
+		var rec []Weight
+		used := 0
+		for i := range plates {
+			for c := maxCount(i, s); c >= 0; c-- {
+				r := table[i+1][s-c*units[i]]
+				if r == unreachable || r+cost(i, c) != table[i][s] {
+					continue
+				}
+				for j := 0; j < c; j++ {
+					rec = append(rec, plates[i].weight)
+				}
+				used += c * thick[i]
+				s -= c * units[i]
+				break
+			}
+		}
+		sort.Slice(rec, func(i, j int) bool { return rec[i] < rec[j] })
+		return rec, used
+	}
+	plan := func(s int) []Weight {
+		rec, used := walk(table, cost, s)
+		if thinnest != nil && used > room {
+			rec, _ = walk(thinnest, thickness, s)
+		}
+		return rec
+	}
+
+	var sol solution
+	s := min(down, total)
+	for !fits(s) {
+		s--
+	}
+	sol.below = plan(s)
+	for s := up; s <= total; s++ {
+		if fits(s) {
+			sol.above = plan(s)
+			sol.over = true
+			break
+		}
+	}
+	return sol, nil
+}
+
+// fill sets next[s] to the lowest cost of reaching s steps with up to pairs (unlimited
+// when negative) of a plate that is u steps, on top of the costs in prev. Counts under
+// from are costed one at a time, while every count from it up costs cost(from) plus
+// slope for each plate after it. That lets those counts be found with a sliding window
+// minimum over every remainder of u, instead of trying every count for every s.
+func fill(next, prev []int, u, pairs, from, slope int, cost func(int) int) {
+	base := cost(from) - from*slope
+	window := pairs < 0 || pairs >= from
+	queue := make([]int, 0, len(next)/u+1)
+	for r := 0; r < u && r < len(next); r++ {
+		n := (len(next) - 1 - r) / u
+		val := func(j int) int { return prev[r+j*u] - j*slope }
+		queue, head := queue[:0], 0
+		for k := 0; k <= n; k++ {
+			best := unreachable
+			if window {
+				if j := k - from; j >= 0 && prev[r+j*u] != unreachable {
+					v := val(j)
+					for len(queue) > head && val(queue[len(queue)-1]) >= v {
+						queue = queue[:len(queue)-1]
+					}
+					queue = append(queue, j)
+				}
+				for pairs >= 0 && head < len(queue) && queue[head] < k-pairs {
+					head++
+				}
+				if head < len(queue) {
+					best = val(queue[head]) + k*slope + base
+				}
+			}
+			for c := 0; c < from && c <= k && (pairs < 0 || c <= pairs); c++ {
+				if p := prev[r+(k-c)*u]; p != unreachable && p+cost(c) < best {
+					best = p + cost(c)
+				}
+			}
+			next[r+k*u] = best
+		}
+	}
+}
+
+// reachable returns every total for both sides of the bar, up to and including max,
+// that can be built from the plates, sorted lightest first.
+func reachable(max Weight, plates []stock) ([]Weight, error) {
+	if len(plates) == 0 {
+		return nil, ErrNoPlatesFound
+	}
+	if max < 0 {
+		return nil, nil
+	}
+	units := make([]int, len(plates))
+	step := 0
+	for i, p := range plates {
+		if p.weight <= 0 {
+			return nil, ErrInvalidWeightsPlates
+		}
+		units[i] = int(p.weight) * 2
+		step = gcd(step, units[i])
+	}
+	if int64(max)/int64(step) > maxSolverStates {
+		return nil, ErrWeightOutOfRange
+	}
+	total := int(max) / step
+
+	// built[s] is true when s steps can be built, and left[s] is the
+	// number of the current plate still available after building s.
+	built := make([]bool, total+1)
+	left := make([]int, total+1)
+	built[0] = true
+	for i, p := range plates {
+		u := units[i] / step
+		for s := 0; s <= total; s++ {
+			switch {
+			case built[s]:
+				left[s] = p.pairs
+			case s >= u && built[s-u] && left[s-u] != 0:
+				built[s] = true
+				left[s] = left[s-u] - 1
+			}
+		}
+	}
+	var totals []Weight
+	for s, ok := range built {
+		if ok {
+			totals = append(totals, Weight(s*step))
+		}
+	}
+	return totals, nil
+}
+
+// fit returns the heaviest load for one side of the bar that fits on a sleeve of
+// that many millimeters, the boolean is false when there is no limit because the
+// sleeve or the thickness of an unlimited plate is unknown.
+func fit(sleeve float64, plates []stock) (Weight, bool) {
+	if !(sleeve > 0) {
+		return 0, false
+	}
+	// an unlimited plate without a thickness fills any sleeve, so there is no
+	// limit to find and nothing to allocate.
+	for _, p := range plates {
+		if p.pairs < 0 && math.Round(p.thickness*thicknessResolution) <= 0 {
+			return 0, false
+		}
+	}
+	room := int(math.Floor(sleeve*thicknessResolution + 1e-6))
+	// heaviest[t] is the heaviest load that is at most t thick.
+	heaviest := make([]Weight, room+1)
+	for _, p := range plates {
+		t := int(math.Round(p.thickness * thicknessResolution))
+		if t <= 0 {
+			for i := range heaviest {
+				heaviest[i] += Weight(p.pairs) * p.weight
+			}
+			continue
+		}
+		n := room / t
+		if p.pairs >= 0 && p.pairs < n {
+			n = p.pairs
+		}
+		// split the count into powers of two, so every count up to n
+		// can be made from at most one of each group.
+		for k := 1; n > 0; k *= 2 {
+			c := min(k, n)
+			n -= c
+			for i := room; i >= c*t; i-- {
+				if w := heaviest[i-c*t] + Weight(c)*p.weight; w > heaviest[i] {
+					heaviest[i] = w
+				}
+			}
+		}
+	}
+	return heaviest[room], true
+}
+
+// gcd returns the greatest common divisor of two integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
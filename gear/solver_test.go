@@ -0,0 +1,201 @@
+package gear
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Policy
+			expected string
+		}{
+			{FewestPlates, "fewest plates"},
+			{HeaviestFirst, "heaviest first"},
+			{FewestChanges, "fewest changes"},
+			{Policy(9), ""},
+		}
+		for _, test := range tt {
+			if test.input.String() != test.expected {
+				t.Error("match failed for", test)
+			}
+		}
+	})
+	t.Run("MarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		o, _ := HeaviestFirst.MarshalJSON()
+		if !bytes.Equal(o, []byte(`"heaviest first"`)) {
+			t.Error("unexpected json:", string(o))
+		}
+	})
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    []byte
+			expected Policy
+			err      error
+		}{
+			{[]byte(`"fewest changes"`), FewestChanges, nil},
+			{[]byte(`"blah"`), FewestPlates, ErrInvalidPolicy},
+			{[]byte(`false`), FewestPlates, errors.New("json: cannot unmarshal bool into Go value of type string")},
+		}
+		for _, test := range tt {
+			var p Policy
+			if err := p.UnmarshalJSON(test.input); err != nil {
+				if err.Error() != test.err.Error() {
+					t.Error("got:", err, "expected:", test.err)
+				}
+			} else if p != test.expected {
+				t.Error("test failed for:", test)
+			}
+		}
+	})
+}
+
+// TestSolveMemory isn't parallel, so that only its own allocations are counted.
+func TestSolveMemory(t *testing.T) {
+	g := Default(LBS)
+	g.Plates = Plates{Weights: weights(0.005, 0.015, 2.5, 45), Unit: LBS}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := g.Sequence(weights(300, 400, 500))
+	runtime.ReadMemStats(&after)
+	if err != nil && !errors.Is(err, ErrWeightOutOfRange) {
+		t.Error("unexpected error:", err)
+	}
+	if a := after.TotalAlloc - before.TotalAlloc; a > 256<<20 {
+		t.Error("expected the solver tables to be bounded:", a>>20, "MB")
+	}
+}
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+	bumpers := Plates{Weights: weights(1.25, 15, 20, 25), Unit: KG}
+	odd := Plates{Weights: weights(2.5, 15, 20), Unit: KG}
+	fives := Plates{Weights: weights(5, 15, 20), Unit: KG}
+	limited := Plates{Weights: weights(10, 25, 45), Unit: LBS}
+	limited.SetCount(45*Precision, 2)
+	limited.SetCount(25*Precision, 2)
+	limited.SetCount(10*Precision, 4)
+
+	tt := []struct {
+		target   float64
+		plates   Plates
+		policy   Policy
+		previous []Weight
+		expected []Weight
+		err      error
+	}{
+		{35, bumpers, FewestPlates, nil, weights(15, 20), nil},
+		{36, bumpers, FewestPlates, nil, weights(15, 20), nil},
+		{36.25, bumpers, FewestPlates, nil, weights(1.25, 15, 20), nil},
+		{30, odd, FewestPlates, nil, weights(15, 15), nil},
+		{30, odd, HeaviestFirst, nil, weights(2.5, 2.5, 2.5, 2.5, 20), nil},
+		{35, fives, FewestPlates, weights(15, 15), weights(15, 20), nil},
+		{35, fives, FewestChanges, weights(15, 15), weights(5, 15, 15), nil},
+		{35, fives, FewestChanges, nil, weights(15, 20), nil},
+		{1000, limited, FewestPlates, nil, weights(10, 10, 25, 45), nil},
+		{0, limited, FewestPlates, nil, []Weight{}, nil},
+		{10, Plates{}, FewestPlates, nil, []Weight{}, ErrNoPlatesFound},
+		{10, odd, Policy(9), nil, []Weight{}, ErrInvalidPolicy},
+		{1e12, odd, FewestPlates, nil, []Weight{}, ErrWeightOutOfRange},
+	}
+	for i, test := range tt {
+		o, err := solve(NewWeight(test.target*2), test.plates.stock(), 0, test.policy, test.previous)
+		if err != test.err {
+			t.Error("unexpected error:", i, err, test.err)
+		} else if !equal(o.below, test.expected) {
+			t.Error("unexpected result:", i, o.below, test.expected)
+		}
+	}
+	t.Run("above", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			target   float64
+			plates   Plates
+			expected []Weight
+			over     bool
+		}{
+			{36, bumpers, weights(1.25, 15, 20), true},
+			{35, bumpers, weights(15, 20), true},
+			{31, odd, weights(2.5, 15, 15), true},
+			{77.5, limited, weights(10, 25, 45), true},
+			{90, limited, weights(10, 10, 25, 45), true},
+			{95, limited, nil, false},
+		}
+		for i, test := range tt {
+			o, err := solve(NewWeight(test.target*2), test.plates.stock(), 0, FewestPlates, nil)
+			if err != nil {
+				t.Error("unexpected error:", i, err)
+			} else if o.over != test.over || (o.over && !equal(o.above, test.expected)) {
+				t.Error("unexpected result:", i, o.above, o.over, test.expected)
+			}
+		}
+	})
+	t.Run("sleeve", func(t *testing.T) {
+		t.Parallel()
+		thin := Plates{Weights: weights(10, 45), Unit: LBS}
+		thin.SetThickness(45*Precision, 100)
+		thin.SetThickness(10*Precision, 10)
+		tt := []struct {
+			target float64
+			sleeve float64
+			below  []Weight
+			above  []Weight
+			over   bool
+		}{
+			{100, 0, weights(10, 45, 45), weights(10, 45, 45), true},
+			{100, 150, weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10), weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10), true},
+			{101, 150, weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10), weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10), true},
+			{45, 150, weights(45), weights(45), true},
+			{200, 150, weights(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10), nil, false},
+		}
+		for i, test := range tt {
+			o, err := solve(NewWeight(test.target*2), thin.stock(), test.sleeve, FewestPlates, nil)
+			if err != nil {
+				t.Error("unexpected error:", i, err)
+			} else if !equal(o.below, test.below) || o.over != test.over || (o.over && !equal(o.above, test.above)) {
+				t.Error("unexpected result:", i, o, test.below, test.above)
+			}
+		}
+	})
+}
+
+func TestFit(t *testing.T) {
+	t.Parallel()
+	thin := Plates{Weights: weights(10, 45), Unit: LBS}
+	thin.SetThickness(45*Precision, 100)
+	thin.SetThickness(10*Precision, 10)
+	limited := thin
+	limited.SetCount(10*Precision, 8)
+	unknown := Plates{Weights: weights(10, 45), Unit: LBS}
+	unknown.SetThickness(45*Precision, 100)
+	unknownLimited := unknown
+	unknownLimited.SetCount(10*Precision, 2)
+
+	tt := []struct {
+		plates   Plates
+		sleeve   float64
+		expected float64
+		ok       bool
+	}{
+		{thin, 150, 150, true},
+		{thin, 0, 0, false},
+		{limited, 150, 85, true},
+		{unknown, 150, 0, false},
+		// nothing is allocated for the sleeve when an unlimited plate has no thickness.
+		{unknown, 1e15, 0, false},
+		{unknownLimited, 150, 55, true},
+	}
+	for i, test := range tt {
+		o, ok := fit(test.sleeve, test.plates.stock())
+		if o != NewWeight(test.expected) || ok != test.ok {
+			t.Error("unexpected result:", i, o, ok, test.expected, test.ok)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package gear
+
+// Totals returns a Load for every total weight, up to and including max, that can be
+// loaded on the bar, sorted lightest first. The first Load is always the empty bar.
+// The plates of each Load are the fewest plates for that total that fit on the sleeves.
+// It returns ErrInputLessThanBar when max is lighter than the bar.
+func (g Gear) Totals(max Weight) ([]Load, error) {
+	if err := g.Valid(); err != nil {
+		return nil, err
+	}
+	bar, plates, err := g.barFromWeight(max)
+	if err != nil {
+		return nil, err
+	}
+	loads := []Load{{Weight: bar}}
+	if g.Bar.Fixed {
+		return loads, nil
+	}
+	st := g.stock()
+	totals, err := reachable(g.toPlates(plates), st)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range totals {
+		if t == 0 {
+			continue
+		}
+		sol, err := solve(t, st, g.Bar.Sleeve, FewestPlates, nil)
+		if err != nil {
+			return nil, err
+		}
+		// totals that only the Inventory allows can still be too thick for the sleeves.
+		if sum(sol.below)*2 != t {
+			continue
+		}
+		loads = append(loads, Load{Weight: bar + g.fromPlates(t), Plates: g.platesFromPlates(sol.below)})
+	}
+	return loads, nil
+}
+
+// Next takes a weight in the units of Gear and returns the Load of the lightest weight
+// heavier than it that can be loaded on the bar, the next jump from that weight. A weight
+// lighter than the bar jumps to the empty bar. It returns ErrInsufficientPlates when
+// nothing heavier can be loaded, or the same errors as Load when the weight is out of reach.
+func (g Gear) Next(weight Weight) (Load, error) {
+	if err := g.Valid(); err != nil {
+		return Load{}, err
+	}
+	if bar, _ := g.Min(); weight < bar {
+		return Load{Weight: bar}, nil
+	}
+	g.Rounding = Ceiling
+	l, err := g.Load(weight+1, FewestPlates, nil)
+	if err != nil {
+		return Load{}, err
+	}
+	if l.Weight <= weight {
+		return Load{}, ErrInsufficientPlates
+	}
+	return l, nil
+}
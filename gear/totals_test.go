@@ -0,0 +1,107 @@
+package gear
+
+import "testing"
+
+func TestTotals(t *testing.T) {
+	t.Parallel()
+	g := Gear{
+		Unit:   LBS,
+		Bar:    Bar{Weight: 45 * Precision, Unit: LBS},
+		Plates: Plates{Weights: weights(2.5, 10), Unit: LBS},
+	}
+	limited := g
+	limited.Plates.SetCount(10*Precision, 2)
+	limited.Plates.SetCount(NewWeight(2.5), 2)
+	sleeve := g
+	sleeve.Bar.Sleeve = 30
+	sleeve.Plates.SetThickness(10*Precision, 20)
+	sleeve.Plates.SetThickness(NewWeight(2.5), 15)
+	fixed := g
+	fixed.Bar.Fixed = true
+
+	tt := []struct {
+		gear     Gear
+		max      Weight
+		expected []Load
+		err      error
+	}{
+		{g, 70 * Precision, []Load{
+			{Weight: 45 * Precision},
+			{Weight: 50 * Precision, Plates: weights(2.5)},
+			{Weight: 55 * Precision, Plates: weights(2.5, 2.5)},
+			{Weight: 60 * Precision, Plates: weights(2.5, 2.5, 2.5)},
+			{Weight: 65 * Precision, Plates: weights(10)},
+			{Weight: 70 * Precision, Plates: weights(2.5, 10)},
+		}, nil},
+		{limited, 100 * Precision, []Load{
+			{Weight: 45 * Precision},
+			{Weight: 50 * Precision, Plates: weights(2.5)},
+			{Weight: 65 * Precision, Plates: weights(10)},
+			{Weight: 70 * Precision, Plates: weights(2.5, 10)},
+		}, nil},
+		{sleeve, 75 * Precision, []Load{
+			{Weight: 45 * Precision},
+			{Weight: 50 * Precision, Plates: weights(2.5)},
+			{Weight: 55 * Precision, Plates: weights(2.5, 2.5)},
+			{Weight: 65 * Precision, Plates: weights(10)},
+		}, nil},
+		{fixed, 100 * Precision, []Load{{Weight: 45 * Precision}}, nil},
+		{g, 45 * Precision, []Load{{Weight: 45 * Precision}}, nil},
+		{g, 40 * Precision, nil, ErrInputLessThanBar},
+		{Gear{Unit: 5}, 100 * Precision, nil, ErrInvalidUnitGear},
+	}
+	for i, test := range tt {
+		loads, err := test.gear.Totals(test.max)
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if len(loads) != len(test.expected) {
+			t.Error("unexpected totals:", i, loads, test.expected)
+			continue
+		}
+		for j, l := range loads {
+			e := test.expected[j]
+			if l.Weight != e.Weight || !equal(l.Plates, e.Plates) {
+				t.Error("unexpected load:", i, j, l, e)
+			}
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+	g := Gear{
+		Unit:   LBS,
+		Bar:    Bar{Weight: 45 * Precision, Unit: LBS},
+		Plates: Plates{Weights: weights(2.5, 10), Unit: LBS},
+	}
+	g.Rounding = Nearest
+	limited := g
+	limited.Plates.SetCount(10*Precision, 2)
+	limited.Plates.SetCount(NewWeight(2.5), 2)
+	fixed := g
+	fixed.Bar.Fixed = true
+
+	tt := []struct {
+		gear     Gear
+		weight   Weight
+		expected Load
+		err      error
+	}{
+		{g, 45 * Precision, Load{Weight: 50 * Precision, Plates: weights(2.5)}, nil},
+		{g, 60 * Precision, Load{Weight: 65 * Precision, Plates: weights(10)}, nil},
+		{g, 62 * Precision, Load{Weight: 65 * Precision, Plates: weights(10)}, nil},
+		{g, 20 * Precision, Load{Weight: 45 * Precision}, nil},
+		{limited, 50 * Precision, Load{Weight: 65 * Precision, Plates: weights(10)}, nil},
+		{limited, 70 * Precision, Load{}, ErrInsufficientPlates},
+		{fixed, 45 * Precision, Load{}, ErrInsufficientPlates},
+		{Gear{Unit: 5}, 45 * Precision, Load{}, ErrInvalidUnitGear},
+	}
+	for i, test := range tt {
+		l, err := test.gear.Next(test.weight)
+		if err != test.err || l.Weight != test.expected.Weight || !equal(l.Plates, test.expected.Plates) {
+			t.Error("unexpected next:", i, l, err, test.expected)
+		}
+	}
+}
@@ -4,11 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 )
 
 const (
-	// ConversionFactorKGtoLBS is the weight of 1 KG in LBS
-	ConversionFactorKGtoLBS float64 = 2.20462262185
+	// ConversionFactorLBStoKG is the exact weight of 1 LBS in KG
+	ConversionFactorLBStoKG float64 = 0.45359237
+	// ConversionFactorSTONEtoLBS is the weight of 1 STONE in LBS
+	ConversionFactorSTONEtoLBS float64 = 14
+	// ConversionFactorPOODtoKG is the weight of 1 POOD in KG
+	ConversionFactorPOODtoKG float64 = 16.3804964
 )
 
 var (
@@ -24,12 +29,15 @@ const (
 	KG Unit = iota
 	// LBS represents the imperial unit for pounds
 	LBS
-	// TODO (NST): maybe add support for POOD?
+	// STONE represents the imperial unit of 14 pounds
+	STONE
+	// POOD represents the Russian unit of 16.3804964 Kilograms, used for kettlebells
+	POOD
 )
 
 // String prints the human readable value from the enum
 func (u Unit) String() string {
-	p := []string{"KG", "LBS"}
+	p := []string{"KG", "LBS", "STONE", "POOD"}
 	if int(u) < len(p) {
 		return p[u]
 	}
@@ -38,8 +46,10 @@ func (u Unit) String() string {
 
 // StringToUnit is a simple map that converts from string to Unit type
 var stringToUnit = map[string]Unit{
-	"KG":  KG,
-	"LBS": LBS,
+	"KG":    KG,
+	"LBS":   LBS,
+	"STONE": STONE,
+	"POOD":  POOD,
 }
 
 // UnitFromString takes a string and returns a unit or error
@@ -70,26 +80,38 @@ func (u *Unit) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// Valid checks that a Unit is either KG or LBS and returns a boolean
+// Valid checks that a Unit is one of the enumerated units and returns a boolean
 func (u Unit) Valid() bool {
-	return u == KG || u == LBS
+	return int(u) < len(stringToUnit)
 }
 
-// ConvertFromTo takes a float64 and a from and to unit and converts the float into the
+// micrograms is the exact weight of one of each Unit in micrograms.
+var micrograms = [...]int64{
+	KG:    1e9,
+	LBS:   453592370,
+	STONE: 6350293180,
+	POOD:  16380496400,
+}
+
+// ConvertFromTo takes a Weight and a from and to unit and converts the Weight into the
 // requested unit. For instance if you wanted to convert 55 lbs to kg,
-// Convert(55.0, LBS, KG) would return 24.9476, nil.
-func ConvertFromTo(w float64, from Unit, to Unit) (float64, error) {
+// Convert(NewWeight(55), LBS, KG) would return 24.948, nil. The conversion is exact
+// before it is rounded to the closest Weight.
+func ConvertFromTo(w Weight, from Unit, to Unit) (Weight, error) {
 	if !from.Valid() || !to.Valid() {
 		return 0, ErrInvalidUnit
 	}
 	if from == to {
 		return w, nil
 	}
-	if to == KG {
-		return w / ConversionFactorKGtoLBS, nil
-	}
-	if to == LBS {
-		return w * ConversionFactorKGtoLBS, nil
+	n := new(big.Int).Mul(big.NewInt(int64(w)), big.NewInt(micrograms[from]))
+	d := big.NewInt(micrograms[to])
+	// round half away from zero by adding half the divisor before truncating.
+	half := new(big.Int).Rsh(d, 1)
+	if n.Sign() < 0 {
+		n.Sub(n, half)
+	} else {
+		n.Add(n, half)
 	}
-	return 0, ErrInvalidUnit
+	return Weight(n.Quo(n, d).Int64()), nil
 }
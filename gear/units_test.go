@@ -15,6 +15,8 @@ func TestUnitFromString(t *testing.T) {
 	}{
 		{"KG", KG, nil},
 		{"LBS", LBS, nil},
+		{"STONE", STONE, nil},
+		{"POOD", POOD, nil},
 		{"FOO", 0, ErrInvalidUnit},
 	}
 	for _, test := range tt {
@@ -38,8 +40,10 @@ func TestUnit(t *testing.T) {
 		}{
 			{KG, []byte(`"KG"`)},
 			{LBS, []byte(`"LBS"`)},
-			{Unit(2), []byte(`""`)},
-			{Unit(3), []byte(`""`)},
+			{STONE, []byte(`"STONE"`)},
+			{POOD, []byte(`"POOD"`)},
+			{Unit(4), []byte(`""`)},
+			{Unit(5), []byte(`""`)},
 		}
 		for _, test := range tt {
 			o, _ := test.input.MarshalJSON()
@@ -57,6 +61,7 @@ func TestUnit(t *testing.T) {
 		}{
 			{[]byte(`"KG"`), KG, nil},
 			{[]byte(`"LBS"`), LBS, nil},
+			{[]byte(`"POOD"`), POOD, nil},
 			{[]byte(`"BLAH"`), KG, ErrInvalidUnit},
 			{[]byte(`""`), KG, ErrInvalidUnit},
 			{[]byte(``), KG, errors.New("unexpected end of JSON input")},
@@ -80,8 +85,10 @@ func TestUnit(t *testing.T) {
 		}{
 			{KG, "KG"},
 			{LBS, "LBS"},
-			{Unit(2), ""},
-			{Unit(3), ""},
+			{STONE, "STONE"},
+			{POOD, "POOD"},
+			{Unit(4), ""},
+			{Unit(5), ""},
 		}
 
 		for _, test := range tt {
@@ -100,19 +107,27 @@ func TestUnit(t *testing.T) {
 			err      error
 		}{
 			{0, LBS, KG, 0, nil},
-			{55.0, LBS, KG, 24.947580349986147, nil},
-			{55.0, KG, LBS, 121.25424420175, nil},
+			{55.0, LBS, KG, 24.948, nil},
+			{55.0, KG, LBS, 121.254, nil},
 			{0, 5, LBS, 0, ErrInvalidUnit},
 			{0, LBS, 5, 0, ErrInvalidUnit},
 			{0, 5, KG, 0, ErrInvalidUnit},
+			{2, STONE, LBS, 28, nil},
+			{28, LBS, STONE, 2, nil},
+			{1, POOD, KG, 16.38, nil},
+			{32.7609928, KG, POOD, 2, nil},
+			{10, STONE, KG, 63.503, nil},
+			{2, POOD, STONE, 5.159, nil},
+			{5, STONE, STONE, 5, nil},
+			{0, STONE, 5, 0, ErrInvalidUnit},
 		}
 
 		for _, test := range tt {
-			o, err := ConvertFromTo(test.input, test.from, test.to)
+			o, err := ConvertFromTo(NewWeight(test.input), test.from, test.to)
 			if err != test.err {
 				t.Error("expected error mismatch:", err, test.err)
 			}
-			if o != test.expected {
+			if o != NewWeight(test.expected) {
 				t.Error("unexpected output:", o, test.expected)
 			}
 		}
@@ -0,0 +1,116 @@
+package gear
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Weight is an exact decimal weight, stored as a whole number of thousandths of a
+// unit. The unit is kept alongside the Weight, for example by Bar or Plates.
+type Weight int64
+
+// Precision is the Weight of one whole unit, a Weight is exact to a thousandth of a unit.
+const Precision Weight = 1000
+
+var (
+	// ErrInvalidWeight is returned when a Weight can't be parsed.
+	ErrInvalidWeight = errors.New("invalid weight")
+)
+
+// NewWeight takes a float64 number of units and returns the closest Weight.
+func NewWeight(f float64) Weight {
+	return Weight(math.Round(f * float64(Precision)))
+}
+
+// weights takes a float64 number of units for each weight and returns the closest Weights.
+func weights(fs ...float64) []Weight {
+	w := make([]Weight, len(fs))
+	for i, f := range fs {
+		w[i] = NewWeight(f)
+	}
+	return w
+}
+
+// ParseWeight takes a decimal number of units, such as "102.5", and returns the exact
+// Weight. Any digits past a thousandth are rounded half away from zero.
+func ParseWeight(s string) (Weight, error) {
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, ErrInvalidWeight
+		}
+		return NewWeight(f), nil
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidWeight
+	}
+	var w Weight
+	for _, d := range whole {
+		if d < '0' || d > '9' || w > math.MaxInt64/10/Precision {
+			return 0, ErrInvalidWeight
+		}
+		w = w*10 + Weight(d-'0')
+	}
+	w *= Precision
+	step := Precision
+	for i, d := range frac {
+		if d < '0' || d > '9' {
+			return 0, ErrInvalidWeight
+		}
+		if step > 1 {
+			step /= 10
+			w += Weight(d-'0') * step
+		} else if i == 3 && d >= '5' {
+			w++
+		}
+	}
+	if neg {
+		w = -w
+	}
+	return w, nil
+}
+
+// Float64 returns the Weight as a float64 number of units.
+func (w Weight) Float64() float64 {
+	return float64(w) / float64(Precision)
+}
+
+// String prints the Weight as a decimal number of units without trailing zeros.
+func (w Weight) String() string {
+	s := ""
+	if w < 0 {
+		s, w = "-", -w
+	}
+	s += strconv.FormatInt(int64(w/Precision), 10)
+	if frac := w % Precision; frac != 0 {
+		f := strconv.FormatInt(int64(frac+Precision), 10)[1:]
+		s += "." + strings.TrimRight(f, "0")
+	}
+	return s
+}
+
+// Mul multiplies the Weight by f and returns the closest Weight.
+func (w Weight) Mul(f float64) Weight {
+	return Weight(math.Round(float64(w) * f))
+}
+
+// MarshalJSON encodes the Weight as an exact JSON number.
+func (w Weight) MarshalJSON() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, or a string holding a number, into a Weight.
+func (w *Weight) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	weight, err := ParseWeight(s)
+	if err != nil {
+		return err
+	}
+	*w = weight
+	return nil
+}
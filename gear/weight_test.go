@@ -0,0 +1,109 @@
+package gear
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseWeight(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		input    string
+		expected Weight
+		err      error
+	}{
+		{"45", 45 * Precision, nil},
+		{"102.5", 102500, nil},
+		{"0.1", 100, nil},
+		{".25", 250, nil},
+		{"1.", 1 * Precision, nil},
+		{"-20", -20 * Precision, nil},
+		{"+2.5", 2500, nil},
+		{"1.2345", 1235, nil},
+		{"1.2344", 1234, nil},
+		{"1.0009999", 1001, nil},
+		{"1e2", 100 * Precision, nil},
+		{"", 0, ErrInvalidWeight},
+		{".", 0, ErrInvalidWeight},
+		{"foo", 0, ErrInvalidWeight},
+		{"1.2.3", 0, ErrInvalidWeight},
+		{"1e999", 0, ErrInvalidWeight},
+		{"99999999999999999999", 0, ErrInvalidWeight},
+	}
+	for _, test := range tt {
+		o, err := ParseWeight(test.input)
+		if err != test.err {
+			t.Error("unexpected error:", test.input, err, test.err)
+		}
+		if o != test.expected {
+			t.Error("unexpected weight:", test.input, int64(o), int64(test.expected))
+		}
+	}
+}
+
+func TestWeight(t *testing.T) {
+	t.Parallel()
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Weight
+			expected string
+		}{
+			{0, "0"},
+			{45 * Precision, "45"},
+			{102500, "102.5"},
+			{1250, "1.25"},
+			{5, "0.005"},
+			{-2500, "-2.5"},
+			{NewWeight(0.1 + 0.2), "0.3"},
+		}
+		for _, test := range tt {
+			if o := test.input.String(); o != test.expected {
+				t.Error("match failed for", int64(test.input), o, test.expected)
+			}
+		}
+	})
+	t.Run("Mul", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    Weight
+			factor   float64
+			expected Weight
+		}{
+			{200 * Precision, .65, 130 * Precision},
+			{315 * Precision, .85, 267750},
+			{NewWeight(1.001), .5, 501},
+			{NewWeight(100), 1.025, 102500},
+		}
+		for _, test := range tt {
+			if o := test.input.Mul(test.factor); o != test.expected {
+				t.Error("unexpected result:", test.input, test.factor, o, test.expected)
+			}
+		}
+	})
+	t.Run("Float64", func(t *testing.T) {
+		t.Parallel()
+		if f := NewWeight(102.5).Float64(); f != 102.5 {
+			t.Error("unexpected float:", f)
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		b, err := json.Marshal(Load{Weight: 102500, Plates: weights(1.25, 45)})
+		if err != nil || !bytes.Equal(b, []byte(`{"weight":102.5,"plates":[1.25,45]}`)) {
+			t.Error("unexpected json:", string(b), err)
+		}
+		var l Load
+		if err := json.Unmarshal(b, &l); err != nil || l.Weight != 102500 || !equal(l.Plates, weights(1.25, 45)) {
+			t.Error("unexpected load:", l, err)
+		}
+		var w Weight
+		if err := json.Unmarshal([]byte(`"2.5"`), &w); err != nil || w != 2500 {
+			t.Error("unexpected weight from string:", w, err)
+		}
+		if err := json.Unmarshal([]byte(`true`), &w); err != ErrInvalidWeight {
+			t.Error("unexpected error:", err)
+		}
+	})
+}
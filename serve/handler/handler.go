@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/calc"
 	"github.com/liftplan/liftplan/gear"
 	"github.com/liftplan/liftplan/serve/handler/components"
 	"github.com/liftplan/liftplan/strategy/fto"
@@ -34,6 +35,8 @@ var (
 	baseTemplate string
 	//go:embed templates/main.form.go.html
 	mainFormTemplate string
+	//go:embed templates/calc.go.html
+	calcTemplate string
 )
 
 func badRequestError(w http.ResponseWriter, err error) {
@@ -94,6 +97,88 @@ func Plan() http.HandlerFunc {
 	}
 }
 
+// Calc returns the barbell calculator, with a result when the query has a weight
+func Calc() http.HandlerFunc {
+	t, err := pageTemplate(calcTemplate, "calc")
+	if err != nil {
+		log.Fatal(err)
+	}
+	gf := gear.FormFields()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			calcSubmit(w, r)
+		case "GET":
+			if wantsJSON(r) {
+				w.Header().Add("Content-Type", "application/json")
+				renderCalcJSON(w, r)
+			} else {
+				w.Header().Add("Content-Type", "text/html")
+				renderCalcHTML(t, gf, w, r)
+			}
+			cacheControl(maxAge, w)
+		default:
+			badRequestError(w, fmt.Errorf("invalid request method: %v", r.Method))
+		}
+	}
+}
+
+// CalcOptions represent the HTML Gear options and Result for the calculator page
+type CalcOptions struct {
+	Gear   template.HTML
+	Result template.HTML
+}
+
+func calcSubmit(w http.ResponseWriter, r *http.Request) {
+	r.ParseMultipartForm(maxBytes)
+	c, err := calc.FromValues(r.Form)
+	if err != nil {
+		badRequestError(w, err)
+		return
+	}
+	v, err := c.Values()
+	if err != nil {
+		badRequestError(w, err)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("%v?%v", r.URL.Path, v.Encode()), 301)
+}
+
+func renderCalcJSON(w http.ResponseWriter, r *http.Request) {
+	c, err := calc.FromValues(r.URL.Query())
+	if err != nil {
+		badRequestError(w, err)
+		return
+	}
+	h, err := c.Plan(liftplan.JSON)
+	if err != nil {
+		badRequestError(w, err)
+		return
+	}
+	w.Write(h)
+}
+
+func renderCalcHTML(t *template.Template, gf template.HTML, w http.ResponseWriter, r *http.Request) {
+	opts := CalcOptions{Gear: gf}
+	if q := r.URL.Query(); len(q) > 0 {
+		c, err := calc.FromValues(q)
+		if err != nil {
+			badRequestError(w, err)
+			return
+		}
+		h, err := c.Plan(liftplan.HTML)
+		if err != nil {
+			badRequestError(w, err)
+			return
+		}
+		opts.Result = template.HTML(h)
+	}
+	if err := t.Execute(w, opts); err != nil {
+		badRequestError(w, err)
+		return
+	}
+}
+
 func formSubmit(w http.ResponseWriter, r *http.Request) {
 	r.ParseMultipartForm(maxBytes)
 	p, err := plannerFromValues(r.Form)
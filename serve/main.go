@@ -21,6 +21,7 @@ func main() {
 	r.HandleFunc("/", handler.Root())
 	r.HandleFunc("/v2", handler.RootV2())
 	r.HandleFunc("/plan", handler.Plan())
+	r.HandleFunc("/calc", handler.Calc())
 	r.Handle("/static/*", http.FileServerFS(staticAssets))
 	http.ListenAndServe(":9000", r)
 }
@@ -0,0 +1,72 @@
+package fto
+
+import "errors"
+
+var (
+	// DefaultBBBPercents are the percents of the training max for the Boring But Big
+	// sets in each week of a cycle, for a Strategy without BBBPercents.
+	DefaultBBBPercents = []float64{50, 60, 70}
+	// ErrInvalidBBBPercents is returned for more BBBPercents than weeks in a cycle, or
+	// a percent that isn't more than zero and at most 100.
+	ErrInvalidBBBPercents = errors.New("invalid bbb percents")
+)
+
+// pairedLifts are the lifts that Boring But Big sets of a paired lift are taken from,
+// for a Movement without a Pair.
+var pairedLifts = map[string]string{
+	"deadlift":       "squat",
+	"squat":          "deadlift",
+	"bench press":    "overhead press",
+	"overhead press": "bench press",
+}
+
+// bbb is the Boring But Big options of a Strategy.
+type bbb struct {
+	percents []float64
+	paired   bool
+}
+
+// bbb returns the Boring But Big options of the Strategy, or ErrInvalidBBBPercents.
+func (s Strategy) bbb() (bbb, error) {
+	percents := s.BBBPercents
+	if len(percents) == 0 {
+		percents = DefaultBBBPercents
+	}
+	if len(percents) > len(workingSetTemplate) {
+		return bbb{}, ErrInvalidBBBPercents
+	}
+	for _, p := range percents {
+		if p <= 0 || p > 100 {
+			return bbb{}, ErrInvalidBBBPercents
+		}
+	}
+	return bbb{percents: percents, paired: s.BBBPaired}, nil
+}
+
+// percent returns the percent of the training max for a week of the cycle, weeks
+// past the last percent use the last percent.
+func (b bbb) percent(wave int) float64 {
+	return b.percents[min(wave, len(b.percents)-1)]
+}
+
+// pair returns the Movement paired with m from the Sessions of a Week, it returns
+// nil when Boring But Big sets aren't paired or the paired lift isn't in the Week.
+func (b bbb) pair(m Movement, sessions []Session) *Movement {
+	if !b.paired {
+		return nil
+	}
+	name := m.Pair
+	if name == "" {
+		name = pairedLifts[m.Name]
+	}
+	if name == "" {
+		return nil
+	}
+	for _, sess := range sessions {
+		if len(sess) > 0 && sess[0].Movement.Name == name {
+			p := sess[0].Movement
+			return &p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package fto
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestStrategyBBB(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		percents []float64
+		expected []float64
+		err      error
+	}{
+		{nil, []float64{50, 60, 70, 70}, nil},
+		{[]float64{50}, []float64{50, 50, 50, 50}, nil},
+		{[]float64{40, 50, 60}, []float64{40, 50, 60, 60}, nil},
+		{[]float64{40, 50, 60, 70}, nil, ErrInvalidBBBPercents},
+		{[]float64{0}, nil, ErrInvalidBBBPercents},
+		{[]float64{101}, nil, ErrInvalidBBBPercents},
+	}
+	for i, test := range tt {
+		b, err := Strategy{BBBPercents: test.percents}.bbb()
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		for wave, e := range test.expected {
+			if p := b.percent(wave); p != e {
+				t.Error("unexpected percent:", i, wave, p, e)
+			}
+		}
+	}
+}
+
+func TestBBBPair(t *testing.T) {
+	t.Parallel()
+	squat := Movement{Name: "squat"}
+	deadlift := Movement{Name: "deadlift"}
+	curl := Movement{Name: "curl"}
+	sessions := []Session{{Set{Movement: deadlift}}, {Set{Movement: squat}}, {Set{Movement: curl}}}
+
+	if p := (bbb{paired: true}).pair(deadlift, sessions); p == nil || p.Name != "squat" {
+		t.Error("unexpected pair:", p)
+	}
+	if p := (bbb{paired: true}).pair(curl, sessions); p != nil {
+		t.Error("unexpected pair:", p)
+	}
+	if p := (bbb{paired: true}).pair(Movement{Name: "bench press"}, sessions); p != nil {
+		t.Error("unexpected pair:", p)
+	}
+	if p := (bbb{}).pair(deadlift, sessions); p != nil {
+		t.Error("unexpected pair:", p)
+	}
+	// the Pair of a Movement replaces the default, and pairs movements outside of it.
+	if p := (bbb{paired: true}).pair(Movement{Name: "deadlift", Pair: "curl"}, sessions); p == nil || p.Name != "curl" {
+		t.Error("unexpected pair:", p)
+	}
+	if p := (bbb{paired: true}).pair(Movement{Name: "curl", Pair: "squat"}, sessions); p == nil || p.Name != "squat" {
+		t.Error("unexpected pair:", p)
+	}
+}
+
+func TestPlanBBB(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:        gear.Default(gear.LBS),
+		Type:        BBB,
+		BBBPercents: []float64{50, 60, 70},
+		BBBPaired:   true,
+	}
+	b, err := s.Plan(liftplan.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p Progression
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range p {
+		for _, sess := range w.Sessions {
+			aux := sess.CountSetType(Auxiliary)
+			if w.Deload {
+				if aux != 0 {
+					t.Error("unexpected bbb sets in a deload:", i)
+				}
+				continue
+			}
+			if aux != 5 {
+				t.Error("unexpected number of bbb sets:", i, aux)
+			}
+			f, _ := sess.first(Auxiliary)
+			if f.Percent != s.BBBPercents[w.Wave] || f.Movement.Name != pairedLifts[sess[0].Movement.Name] {
+				t.Error("unexpected bbb set:", i, f.Percent, f.Movement.Name)
+			}
+		}
+	}
+
+	h, err := s.Plan(liftplan.HTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h, []byte(`<small class="paired">of squat</small>`)) {
+		t.Error("expected the paired lift in the plan")
+	}
+
+	s.BBBPercents = []float64{200}
+	if _, err := s.Plan(liftplan.JSON); err != ErrInvalidBBBPercents {
+		t.Error("unexpected error:", err)
+	}
+}
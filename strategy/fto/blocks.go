@@ -0,0 +1,141 @@
+package fto
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+var (
+	// ErrInvalidBlock is returned for a Block without any cycles.
+	ErrInvalidBlock = errors.New("invalid block")
+	// ErrInvalidTMPercent is returned for a TMPercent that isn't between 0 and 100.
+	ErrInvalidTMPercent = errors.New("invalid training max percent")
+)
+
+// Block is a run of Cycles of a Strategy with its own main sets, supplemental sets and
+// options, such as the leader and anchor cycles of 5/3/1 Forever. TMPercent is the percent
+// of the training max used during the Block, 100 when zero, such as 85 for conservative
+// leaders. Seventh is the week placed after the Block, if any.
+type Block struct {
+	Name      string           `json:"name,omitempty"`
+	Cycles    uint             `json:"cycles"`
+	MainSets  MainSetMode      `json:"main_sets"`
+	Type      StrategyType     `json:"type"`
+	Warmup    bool             `json:"warmup"`
+	JokerSets bool             `json:"joker_sets"`
+	TMPercent float64          `json:"tm_percent,omitempty"`
+	Seventh   *SeventhWeekType `json:"seventh_week,omitempty"`
+}
+
+// Valid checks the Cycles, MainSets, Type, TMPercent and Seventh of a Block.
+func (b Block) Valid() error {
+	if b.Cycles == 0 {
+		return ErrInvalidBlock
+	}
+	if !b.MainSets.Valid() {
+		return ErrInvalidMainSetMode
+	}
+	if b.Type.String() == "" {
+		return ErrInvalidStrategyType
+	}
+	if b.TMPercent < 0 || b.TMPercent > 100 {
+		return ErrInvalidTMPercent
+	}
+	if b.Seventh != nil && b.Seventh.String() == "" {
+		return ErrInvalidSeventhWeekType
+	}
+	return nil
+}
+
+// trainingMax returns the Movement with the TMPercent of its training max.
+func (b Block) trainingMax(m Movement) Movement {
+	if b.TMPercent == 0 || b.TMPercent == 100 {
+		return m
+	}
+	m.TrainingMax = m.TrainingMax.Mul(b.TMPercent / 100)
+	m.Calculated = true
+	return m
+}
+
+// blocks returns the Blocks of the Strategy, or a single Block of every cycle from the
+// options of the Strategy when it has none.
+func (s Strategy) blocks() []Block {
+	if len(s.Blocks) != 0 {
+		return s.Blocks
+	}
+	cycles := s.Cycles
+	if cycles == 0 {
+		cycles = DefaultCycles
+	}
+	return []Block{{
+		Cycles:    cycles,
+		MainSets:  s.MainSets,
+		Type:      s.Type,
+		Warmup:    s.Warmup,
+		JokerSets: s.JokerSets,
+	}}
+}
+
+// blockValues adds the Blocks to the values, keyed by the index of each Block.
+func blockValues(vals url.Values, blocks []Block) {
+	for i, b := range blocks {
+		if b.Name != "" {
+			vals.Set(namespace+fmt.Sprintf(".block.name.%v", i), b.Name)
+		}
+		vals.Set(namespace+fmt.Sprintf(".block.cycles.%v", i), fmt.Sprintf("%v", b.Cycles))
+		vals.Set(namespace+fmt.Sprintf(".block.main.%v", i), b.MainSets.String())
+		vals.Set(namespace+fmt.Sprintf(".block.strategy.%v", i), b.Type.String())
+		vals.Set(namespace+fmt.Sprintf(".block.warmup.%v", i), fmt.Sprintf("%v", b.Warmup))
+		vals.Set(namespace+fmt.Sprintf(".block.jokersets.%v", i), fmt.Sprintf("%v", b.JokerSets))
+		if b.TMPercent != 0 {
+			vals.Set(namespace+fmt.Sprintf(".block.tm.%v", i), fmt.Sprintf("%v", b.TMPercent))
+		}
+		if b.Seventh != nil {
+			vals.Set(namespace+fmt.Sprintf(".block.seventh.%v", i), b.Seventh.String())
+		}
+	}
+}
+
+// blocksFromValues is the inverse of blockValues, reading Blocks until an index has no cycles.
+func blocksFromValues(v url.Values) ([]Block, error) {
+	var blocks []Block
+	for i := 0; ; i++ {
+		c, ok := v[namespace+fmt.Sprintf(".block.cycles.%v", i)]
+		if !ok || c[0] == "" {
+			return blocks, nil
+		}
+		cycles, err := strconv.ParseUint(c[0], 10, 32)
+		if err != nil {
+			return nil, ErrInvalidBlock
+		}
+		b := Block{
+			Name:      v.Get(namespace + fmt.Sprintf(".block.name.%v", i)),
+			Cycles:    uint(cycles),
+			Warmup:    isChecked(namespace+fmt.Sprintf(".block.warmup.%v", i), v),
+			JokerSets: isChecked(namespace+fmt.Sprintf(".block.jokersets.%v", i), v),
+		}
+		if m := v.Get(namespace + fmt.Sprintf(".block.main.%v", i)); m != "" {
+			if b.MainSets, err = MainSetModeFromString(m); err != nil {
+				return nil, err
+			}
+		}
+		if b.Type, err = StrategyTypeFromString(v.Get(namespace + fmt.Sprintf(".block.strategy.%v", i))); err != nil {
+			return nil, err
+		}
+		if tm := v.Get(namespace + fmt.Sprintf(".block.tm.%v", i)); tm != "" {
+			if b.TMPercent, err = strconv.ParseFloat(tm, 64); err != nil {
+				return nil, ErrInvalidTMPercent
+			}
+		}
+		if sw := v.Get(namespace + fmt.Sprintf(".block.seventh.%v", i)); sw != "" {
+			t, err := SeventhWeekTypeFromString(sw)
+			if err != nil {
+				return nil, err
+			}
+			b.Seventh = &t
+		}
+		blocks = append(blocks, b)
+	}
+}
@@ -0,0 +1,147 @@
+package fto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+func leaderAnchor() []Block {
+	deload, test := DeloadWeek, TMTestWeek
+	return []Block{
+		{Name: "Leader", Cycles: 2, MainSets: FivesPro, Type: BBB, Warmup: true, TMPercent: 85, Seventh: &deload},
+		{Name: "Anchor", Cycles: 1, MainSets: PRSets, Type: FSL, Warmup: true, JokerSets: true, Seventh: &test},
+	}
+}
+
+func TestBlockValid(t *testing.T) {
+	t.Parallel()
+	rest := SeventhWeekType(55)
+	tt := []struct {
+		block Block
+		err   error
+	}{
+		{Block{Cycles: 1}, nil},
+		{Block{Cycles: 2, MainSets: FivesPro, Type: BBB, TMPercent: 85}, nil},
+		{Block{}, ErrInvalidBlock},
+		{Block{Cycles: 1, MainSets: MainSetMode(10)}, ErrInvalidMainSetMode},
+		{Block{Cycles: 1, Type: StrategyType(10)}, ErrInvalidStrategyType},
+		{Block{Cycles: 1, TMPercent: -5}, ErrInvalidTMPercent},
+		{Block{Cycles: 1, TMPercent: 105}, ErrInvalidTMPercent},
+		{Block{Cycles: 1, Seventh: &rest}, ErrInvalidSeventhWeekType},
+	}
+	for i, test := range tt {
+		if err := test.block.Valid(); err != test.err {
+			t.Error("unexpected error:", i, err)
+		}
+	}
+}
+
+func TestScheduleBlocks(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		strategy Strategy
+		expected string
+		err      error
+	}{
+		{Strategy{Blocks: leaderAnchor()},
+			"Leader.1.0 Leader.1.1 Leader.1.2 Leader.2.0 Leader.2.1 Leader.2.2 Leader.2.deload " +
+				"Anchor.3.0 Anchor.3.1 Anchor.3.2 Anchor.3.tmtest", nil},
+		// the Cycles and SeventhWeeks of the Strategy are replaced by the Blocks.
+		{Strategy{Cycles: 5, SeventhWeeks: []SeventhWeek{{5, DeloadWeek}}, Blocks: []Block{{Cycles: 1}, {Cycles: 1}}},
+			".1.0 .1.1 .1.2 .2.0 .2.1 .2.2", nil},
+		{Strategy{Blocks: []Block{{Cycles: MaxCycles}, {Cycles: 1}}}, "", ErrInvalidCycles},
+		{Strategy{Blocks: []Block{{Cycles: 1}, {}}}, "", ErrInvalidBlock},
+		{Strategy{Type: StrategyType(10)}, "", ErrInvalidStrategyType},
+	}
+	for i, test := range tt {
+		weeks, err := test.strategy.schedule()
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		var got []string
+		for _, w := range weeks {
+			if w.Seventh != nil {
+				got = append(got, fmt.Sprintf("%v.%v.%v", w.Block.Name, w.Cycle, w.Seventh.Type))
+				continue
+			}
+			got = append(got, fmt.Sprintf("%v.%v.%v", w.Block.Name, w.Cycle, w.Wave))
+		}
+		if g := strings.Join(got, " "); g != test.expected {
+			t.Error("unexpected schedule:", i, g, test.expected)
+		}
+	}
+}
+
+func TestProgressionBlocks(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{{
+			Name:        "squat",
+			TrainingMax: 300 * gear.Precision,
+			Unit:        gear.LBS,
+			Increase:    &Increase{Type: FixedIncrease, Weight: 10 * gear.Precision},
+		}},
+		Gear:   gear.Default(gear.LBS),
+		Blocks: leaderAnchor(),
+	}
+	b, err := s.Plan(liftplan.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p Progression
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 11 {
+		t.Fatal("unexpected number of weeks:", len(p))
+	}
+	tt := []struct {
+		week  int
+		block string
+		tm    gear.Weight
+		sets  map[SetType]int
+		amrap bool
+	}{
+		{0, "Leader", 255 * gear.Precision, map[SetType]int{Warmup: 5, Working: 3, Joker: 0, Auxiliary: 5}, false},
+		{3, "Leader", 263500, map[SetType]int{Warmup: 5, Working: 3, Auxiliary: 5}, false},
+		{6, "Leader", 263500, map[SetType]int{Warmup: 3, Working: 3}, false},
+		{9, "Anchor", 320 * gear.Precision, map[SetType]int{Warmup: 5, Working: 3, Joker: 5, Auxiliary: 1}, true},
+		{10, "Anchor", 320 * gear.Precision, map[SetType]int{Warmup: 5, Working: 4, Auxiliary: 1}, false},
+	}
+	for _, test := range tt {
+		w := p[test.week]
+		sess := w.Sessions[0]
+		if w.Block != test.block || sess[0].Movement.TrainingMax != test.tm {
+			t.Error("unexpected week:", test.week, w.Block, sess[0].Movement.TrainingMax)
+		}
+		sets := map[SetType]int{}
+		var amrap bool
+		for _, set := range sess {
+			sets[set.Type]++
+			amrap = amrap || (set.Type == Working && set.AMRAP)
+		}
+		for st, n := range test.sets {
+			if sets[st] != n {
+				t.Error("unexpected number of sets:", test.week, st, sets[st])
+			}
+		}
+		if amrap != test.amrap {
+			t.Error("unexpected amrap:", test.week, amrap)
+		}
+	}
+
+	h, err := s.Plan(liftplan.HTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(h, []byte("Cycle 3, Anchor")) {
+		t.Error("expected the block in the plan")
+	}
+}
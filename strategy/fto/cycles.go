@@ -0,0 +1,203 @@
+package fto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultCycles is the number of 3 week cycles in a Strategy without Cycles.
+	DefaultCycles uint = 2
+	// MaxCycles is the largest number of cycles that can be planned at once.
+	MaxCycles uint = 20
+	// DefaultDeloadEvery is how many cycles come before each deload by default.
+	DefaultDeloadEvery uint = 2
+)
+
+var (
+	// ErrInvalidSeventhWeekType represents an invalid SeventhWeekType
+	ErrInvalidSeventhWeekType = errors.New("invalid SeventhWeekType")
+	// ErrInvalidCycles is returned for more than MaxCycles.
+	ErrInvalidCycles = errors.New("invalid cycles")
+	// ErrInvalidSeventhWeek is returned for a SeventhWeek that isn't after one of the cycles.
+	ErrInvalidSeventhWeek = errors.New("invalid seventh week")
+	// ErrInvalidDeloadEvery is returned when deloads aren't placed after a number of cycles.
+	ErrInvalidDeloadEvery = errors.New("invalid deload every")
+)
+
+// SeventhWeekType is an ENUM type for the "7th week" protocols placed between cycles.
+type SeventhWeekType uint
+
+const (
+	// DeloadWeek is a week of light sets from the DeloadType of the Strategy.
+	DeloadWeek SeventhWeekType = iota
+	// TMTestWeek works up to the training max for 5 reps to check that it isn't too heavy.
+	TMTestWeek
+)
+
+var stringToSeventhWeekType = map[string]SeventhWeekType{
+	"deload": DeloadWeek,
+	"tmtest": TMTestWeek,
+}
+
+// SeventhWeekTypeFromString takes a string and returns a SeventhWeekType and an error
+func SeventhWeekTypeFromString(s string) (SeventhWeekType, error) {
+	seventhWeekType, ok := stringToSeventhWeekType[s]
+	if !ok {
+		return 0, ErrInvalidSeventhWeekType
+	}
+	return seventhWeekType, nil
+}
+
+// String is the string representation of a seventh week type
+func (s SeventhWeekType) String() string {
+	n := []string{"deload", "tmtest"}
+	if int(s) < len(n) {
+		return n[s]
+	}
+	return ""
+}
+
+// MarshalJSON is the json marshaller for SeventhWeekType
+func (s SeventhWeekType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%v"`, s.String())), nil
+}
+
+// UnmarshalJSON is the json unmarshaller for SeventhWeekType
+func (s *SeventhWeekType) UnmarshalJSON(b []byte) error {
+	var st string
+	if err := json.Unmarshal(b, &st); err != nil {
+		return err
+	}
+	seventhWeekType, err := SeventhWeekTypeFromString(st)
+	*s = seventhWeekType
+	return err
+}
+
+// SeventhWeek is a week of the Type placed After a number of cycles, using the
+// training max of the cycle before it.
+type SeventhWeek struct {
+	After uint            `json:"after"`
+	Type  SeventhWeekType `json:"type"`
+}
+
+// String prints a SeventhWeek in the same format as ParseSeventhWeeks, such as 2:deload.
+func (s SeventhWeek) String() string {
+	return fmt.Sprintf("%v:%v", s.After, s.Type)
+}
+
+// ParseSeventhWeeks takes a comma separated list of seventh weeks, such as
+// "2:deload, 4:tmtest", and returns them sorted by the cycle they come after.
+// The list "none" has no seventh weeks, and is not the same as nil.
+func ParseSeventhWeeks(s string) ([]SeventhWeek, error) {
+	if strings.TrimSpace(s) == "none" {
+		return []SeventhWeek{}, nil
+	}
+	var weeks []SeventhWeek
+	for _, item := range strings.Split(s, ",") {
+		after, t, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok {
+			return nil, ErrInvalidSeventhWeek
+		}
+		n, err := strconv.ParseUint(after, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidSeventhWeek
+		}
+		wt, err := SeventhWeekTypeFromString(t)
+		if err != nil {
+			return nil, err
+		}
+		weeks = append(weeks, SeventhWeek{After: uint(n), Type: wt})
+	}
+	sort.SliceStable(weeks, func(i, j int) bool { return weeks[i].After < weeks[j].After })
+	return weeks, nil
+}
+
+// formatSeventhWeeks is the inverse of ParseSeventhWeeks.
+func formatSeventhWeeks(weeks []SeventhWeek) string {
+	if len(weeks) == 0 {
+		return "none"
+	}
+	s := make([]string, len(weeks))
+	for i, w := range weeks {
+		s[i] = w.String()
+	}
+	return strings.Join(s, ",")
+}
+
+// week is the place of a Week in a Progression. Wave is the week of the cycle, from 0 to 2,
+// unless Seventh is set. Block is the Block the cycle belongs to.
+type week struct {
+	Cycle   uint
+	Wave    int
+	Seventh *SeventhWeek
+	Block   *Block
+}
+
+// schedule returns every week of the Strategy in order. Without Blocks or Cycles there
+// are DefaultCycles, and without Blocks or SeventhWeeks there is a deload after every
+// DeloadEvery cycles, or DefaultDeloadEvery when zero. The seventh weeks of Blocks
+// come after the last cycle of each Block.
+func (s Strategy) schedule() ([]week, error) {
+	if !s.Deload.Valid() {
+		return nil, ErrInvalidDeloadType
+	}
+	blocks := s.blocks()
+	var cycles uint
+	for _, b := range blocks {
+		if err := b.Valid(); err != nil {
+			return nil, err
+		}
+		cycles += b.Cycles
+	}
+	if cycles > MaxCycles {
+		return nil, ErrInvalidCycles
+	}
+	sevenths := s.SeventhWeeks
+	if len(s.Blocks) != 0 {
+		sevenths = []SeventhWeek{}
+		var after uint
+		for _, b := range blocks {
+			after += b.Cycles
+			if b.Seventh != nil {
+				sevenths = append(sevenths, SeventhWeek{After: after, Type: *b.Seventh})
+			}
+		}
+	}
+	if sevenths == nil {
+		every := s.DeloadEvery
+		if every == 0 {
+			every = DefaultDeloadEvery
+		}
+		for c := every; c <= cycles; c += every {
+			sevenths = append(sevenths, SeventhWeek{After: c, Type: DeloadWeek})
+		}
+	}
+	for _, sw := range sevenths {
+		if sw.After == 0 || sw.After > cycles {
+			return nil, ErrInvalidSeventhWeek
+		}
+		if sw.Type.String() == "" {
+			return nil, ErrInvalidSeventhWeekType
+		}
+	}
+	var weeks []week
+	c := uint(1)
+	for i := range blocks {
+		for end := c + blocks[i].Cycles; c < end; c++ {
+			for wave := range workingSetTemplate {
+				weeks = append(weeks, week{Cycle: c, Wave: wave, Block: &blocks[i]})
+			}
+			for j := range sevenths {
+				if sevenths[j].After == c {
+					weeks = append(weeks, week{Cycle: c, Seventh: &sevenths[j], Block: &blocks[i]})
+				}
+			}
+		}
+	}
+	return weeks, nil
+}
@@ -0,0 +1,174 @@
+package fto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestSeventhWeekType(t *testing.T) {
+	t.Parallel()
+	if TMTestWeek.String() != "tmtest" {
+		t.Error(TMTestWeek.String(), "!= tmtest")
+	}
+	if b := SeventhWeekType(55); b.String() != "" {
+		t.Error(b.String(), "!= ''")
+	}
+	b, _ := DeloadWeek.MarshalJSON()
+	if !bytes.Equal(b, []byte(`"deload"`)) {
+		t.Error(string(b), "!=", `"deload"`)
+	}
+	var s SeventhWeekType
+	if err := s.UnmarshalJSON([]byte(`"tmtest"`)); err != nil || s != TMTestWeek {
+		t.Error("unexpected seventh week type:", s, err)
+	}
+	if err := s.UnmarshalJSON([]byte(`"rest"`)); err != ErrInvalidSeventhWeekType {
+		t.Error("unexpected error:", err)
+	}
+	if err := s.UnmarshalJSON([]byte(`false`)); err == nil {
+		t.Error("expected an error for a bool")
+	}
+}
+
+func TestParseSeventhWeeks(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		input    string
+		expected []SeventhWeek
+		err      error
+	}{
+		{"2:deload", []SeventhWeek{{2, DeloadWeek}}, nil},
+		{"4:tmtest, 2:deload", []SeventhWeek{{2, DeloadWeek}, {4, TMTestWeek}}, nil},
+		{"none", []SeventhWeek{}, nil},
+		{"2", nil, ErrInvalidSeventhWeek},
+		{"two:deload", nil, ErrInvalidSeventhWeek},
+		{"2:rest", nil, ErrInvalidSeventhWeekType},
+		{"", nil, ErrInvalidSeventhWeek},
+	}
+	for _, test := range tt {
+		weeks, err := ParseSeventhWeeks(test.input)
+		if err != test.err {
+			t.Error("unexpected error:", test.input, err)
+			continue
+		}
+		if err == nil && (weeks == nil || formatSeventhWeeks(weeks) != formatSeventhWeeks(test.expected)) {
+			t.Error("unexpected seventh weeks:", test.input, weeks)
+		}
+	}
+	if s := formatSeventhWeeks([]SeventhWeek{{2, DeloadWeek}, {4, TMTestWeek}}); s != "2:deload,4:tmtest" {
+		t.Error("unexpected format:", s)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		strategy Strategy
+		expected string
+		err      error
+	}{
+		{Strategy{}, "1.0 1.1 1.2 2.0 2.1 2.2 2.deload", nil},
+		{Strategy{Cycles: 3}, "1.0 1.1 1.2 2.0 2.1 2.2 2.deload 3.0 3.1 3.2", nil},
+		{Strategy{Cycles: 1, SeventhWeeks: []SeventhWeek{}}, "1.0 1.1 1.2", nil},
+		{Strategy{Cycles: 2, SeventhWeeks: []SeventhWeek{{1, TMTestWeek}, {2, DeloadWeek}}},
+			"1.0 1.1 1.2 1.tmtest 2.0 2.1 2.2 2.deload", nil},
+		{Strategy{Cycles: 3, DeloadEvery: 1}, "1.0 1.1 1.2 1.deload 2.0 2.1 2.2 2.deload 3.0 3.1 3.2 3.deload", nil},
+		{Strategy{Cycles: 4, DeloadEvery: 3}, "1.0 1.1 1.2 2.0 2.1 2.2 3.0 3.1 3.2 3.deload 4.0 4.1 4.2", nil},
+		{Strategy{Cycles: 2, DeloadEvery: 1, SeventhWeeks: []SeventhWeek{}}, "1.0 1.1 1.2 2.0 2.1 2.2", nil},
+		{Strategy{Deload: DeloadType(20)}, "", ErrInvalidDeloadType},
+		{Strategy{Cycles: MaxCycles + 1}, "", ErrInvalidCycles},
+		{Strategy{Cycles: 2, SeventhWeeks: []SeventhWeek{{3, DeloadWeek}}}, "", ErrInvalidSeventhWeek},
+		{Strategy{Cycles: 2, SeventhWeeks: []SeventhWeek{{0, DeloadWeek}}}, "", ErrInvalidSeventhWeek},
+		{Strategy{Cycles: 2, SeventhWeeks: []SeventhWeek{{1, SeventhWeekType(55)}}}, "", ErrInvalidSeventhWeekType},
+	}
+	for i, test := range tt {
+		weeks, err := test.strategy.schedule()
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		var got []string
+		for _, w := range weeks {
+			if w.Seventh != nil {
+				got = append(got, fmt.Sprintf("%v.%v", w.Cycle, w.Seventh.Type))
+				continue
+			}
+			got = append(got, fmt.Sprintf("%v.%v", w.Cycle, w.Wave))
+		}
+		if g := strings.Join(got, " "); g != test.expected {
+			t.Error("unexpected schedule:", i, g, test.expected)
+		}
+	}
+}
+
+func TestProgressionCycles(t *testing.T) {
+	t.Parallel()
+	m := Movement{
+		Name:        "squat",
+		TrainingMax: 300 * gear.Precision,
+		Unit:        gear.LBS,
+		Increase:    &Increase{Type: FixedIncrease, Weight: 10 * gear.Precision},
+	}
+	s := Strategy{
+		Movements:    []Movement{m},
+		Gear:         gear.Default(gear.LBS),
+		Type:         FSL,
+		Cycles:       4,
+		SeventhWeeks: []SeventhWeek{{2, TMTestWeek}, {4, DeloadWeek}},
+	}
+	b, err := s.Plan(liftplan.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p Progression
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 14 {
+		t.Fatal("unexpected number of weeks:", len(p))
+	}
+	tt := []struct {
+		week   int
+		cycle  uint
+		tm     gear.Weight
+		deload bool
+		test   bool
+	}{
+		{0, 1, 300 * gear.Precision, false, false},
+		{3, 2, 310 * gear.Precision, false, false},
+		{6, 2, 310 * gear.Precision, false, true},
+		{7, 3, 320 * gear.Precision, false, false},
+		{10, 4, 330 * gear.Precision, false, false},
+		{13, 4, 330 * gear.Precision, true, false},
+	}
+	for _, test := range tt {
+		w := p[test.week]
+		mv := w.Sessions[0][0].Movement
+		if w.Cycle != test.cycle || w.Deload != test.deload || w.TMTest != test.test || mv.TrainingMax != test.tm {
+			t.Error("unexpected week:", test.week, w.Cycle, w.Deload, w.TMTest, mv.TrainingMax)
+		}
+	}
+	if last, _ := p[6].Sessions[0].last(Working); last.Percent != 100 || last.Reps != 5 {
+		t.Error("unexpected tm test set:", last)
+	}
+
+	h, err := s.Plan(liftplan.HTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"Week 14, Cycle 4", "TM TEST", "DELOAD"} {
+		if !bytes.Contains(h, []byte(c)) {
+			t.Error("expected plan to contain:", c)
+		}
+	}
+
+	s.Cycles = MaxCycles + 1
+	if _, err := s.Plan(liftplan.JSON); err != ErrInvalidCycles {
+		t.Error("unexpected error:", err)
+	}
+}
@@ -0,0 +1,107 @@
+package fto
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+// ErrInvalidEstimate is returned for an Estimate without a Weight, or with a Percent
+// that isn't more than 0 and at most 100.
+var ErrInvalidEstimate = errors.New("invalid estimate")
+
+// Estimate records how the TrainingMax of a Movement is derived from a recent set of
+// Weight for Reps, where a single rep is a true 1 rep max. Max is the 1 rep max estimated
+// by the Formula, and Percent is the percent of it used as the training max, such as 85
+// or 90. Weight and Max are in the units of the Movement.
+type Estimate struct {
+	Weight  gear.Weight `json:"weight"`
+	Reps    uint        `json:"reps"`
+	Formula Formula     `json:"formula"`
+	Percent float64     `json:"percent"`
+	Max     gear.Weight `json:"max"`
+}
+
+// NewEstimate takes a set of weight for reps, estimates the 1 rep max with the Formula and
+// returns the Estimate. Zero reps are taken as a single rep, and a zero percent as the TMFactor.
+func NewEstimate(weight gear.Weight, reps uint, f Formula, percent float64) (Estimate, error) {
+	if reps == 0 {
+		reps = 1
+	}
+	if percent == 0 {
+		percent = TMFactor * 100
+	}
+	e := Estimate{Weight: weight, Reps: reps, Formula: f, Percent: percent}
+	if weight <= 0 || percent < 0 || percent > 100 {
+		return e, ErrInvalidEstimate
+	}
+	max, err := f.E1RM(weight, reps)
+	if err != nil {
+		return e, err
+	}
+	e.Max = min(max, MaxTrainingMax)
+	return e, nil
+}
+
+// TrainingMax returns the Percent of the estimated 1 rep max.
+func (e Estimate) TrainingMax() gear.Weight {
+	return e.Max.Mul(e.Percent / 100)
+}
+
+// estimate sets the Estimate of the Movement and the TrainingMax derived from it.
+func (m *Movement) estimate(e Estimate) {
+	m.Estimate = &e
+	m.TrainingMax = e.TrainingMax()
+}
+
+// estimateValues adds the inputs of an Estimate for the movement of the key.
+func estimateValues(vals url.Values, key string, e Estimate) {
+	vals.Set(namespace+".max."+key, e.Weight.String())
+	vals.Set(namespace+".reps."+key, fmt.Sprintf("%v", e.Reps))
+	vals.Set(namespace+".formula."+key, e.Formula.String())
+	vals.Set(namespace+".tmpercent."+key, fmt.Sprintf("%v", e.Percent))
+}
+
+// estimateFromValues returns the Estimate of the movement of the key, and false when it
+// has none. The formula and percent of the movement fall back to those of every movement.
+func estimateFromValues(v url.Values, key string) (Estimate, bool, error) {
+	max := v.Get(namespace + ".max." + key)
+	if max == "" {
+		return Estimate{}, false, nil
+	}
+	weight, err := gear.ParseWeight(max)
+	if err != nil {
+		return Estimate{}, true, fmt.Errorf("unable to convert %v to weight", max)
+	}
+	var reps uint64
+	if r := v.Get(namespace + ".reps." + key); r != "" {
+		if reps, err = strconv.ParseUint(r, 10, 32); err != nil {
+			return Estimate{}, true, ErrInvalidLoggedReps
+		}
+	}
+	var f Formula
+	if fs := valueOr(v, namespace+".formula."+key, namespace+".formula"); fs != "" {
+		if f, err = FormulaFromString(fs); err != nil {
+			return Estimate{}, true, err
+		}
+	}
+	var percent float64
+	if p := valueOr(v, namespace+".tmpercent."+key, namespace+".tmpercent"); p != "" {
+		if percent, err = strconv.ParseFloat(p, 64); err != nil {
+			return Estimate{}, true, ErrInvalidEstimate
+		}
+	}
+	e, err := NewEstimate(weight, uint(reps), f, percent)
+	return e, true, err
+}
+
+// valueOr returns the first value of the key, or of the fallback when it is empty.
+func valueOr(v url.Values, key, fallback string) string {
+	if s := v.Get(key); s != "" {
+		return s
+	}
+	return v.Get(fallback)
+}
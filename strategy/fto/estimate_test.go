@@ -0,0 +1,111 @@
+package fto
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestNewEstimate(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		weight  gear.Weight
+		reps    uint
+		formula Formula
+		percent float64
+		max     gear.Weight
+		tm      gear.Weight
+		err     error
+	}{
+		{400 * gear.Precision, 0, Epley, 0, 400 * gear.Precision, 360 * gear.Precision, nil},
+		{400 * gear.Precision, 1, Brzycki, 85, 400 * gear.Precision, 340 * gear.Precision, nil},
+		{300 * gear.Precision, 6, Epley, 90, 360 * gear.Precision, 324 * gear.Precision, nil},
+		{310 * gear.Precision, 6, Brzycki, 0, 360 * gear.Precision, 324 * gear.Precision, nil},
+		{1900 * gear.Precision, 10, Epley, 0, MaxTrainingMax, 1800 * gear.Precision, nil},
+		{0, 1, Epley, 0, 0, 0, ErrInvalidEstimate},
+		{400 * gear.Precision, 1, Epley, 110, 0, 0, ErrInvalidEstimate},
+		{400 * gear.Precision, 40, Brzycki, 0, 0, 0, ErrInvalidLoggedReps},
+	}
+	for i, test := range tt {
+		e, err := NewEstimate(test.weight, test.reps, test.formula, test.percent)
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if e.Max != test.max || e.TrainingMax() != test.tm {
+			t.Error("unexpected estimate:", i, e.Max, e.TrainingMax())
+		}
+	}
+}
+
+func TestFromValuesEstimate(t *testing.T) {
+	t.Parallel()
+	e, err := NewEstimate(300*gear.Precision, 6, Epley, 85)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", Unit: gear.LBS},
+		},
+		Gear: gear.Default(gear.LBS),
+		Type: FSL,
+	}
+	s.Movements[3].estimate(e)
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := o.Movements[3]; m.Estimate == nil || *m.Estimate != e || m.TrainingMax != 306*gear.Precision {
+		t.Error("unexpected squat:", m.TrainingMax, m.Estimate)
+	}
+	if o.Movements[0].Estimate != nil {
+		t.Error("unexpected deadlift estimate:", o.Movements[0].Estimate)
+	}
+
+	// the form sends the formula and percent once for every movement, and no training max.
+	form := url.Values{}
+	for k, v := range vals {
+		form[k] = v
+	}
+	for _, k := range []string{"fto.tm.squat", "fto.formula.squat", "fto.tmpercent.squat"} {
+		form.Del(k)
+	}
+	form.Set("fto.formula", Brzycki.String())
+	form.Set("fto.tmpercent", "90")
+	o, err = FromValues(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := o.Movements[3]; m.Estimate == nil || m.Estimate.Formula != Brzycki || m.Estimate.Percent != 90 {
+		t.Error("unexpected squat:", m.Estimate)
+	}
+
+	tt := []struct {
+		key, value string
+	}{
+		{"fto.max.squat", "heavy"},
+		{"fto.reps.squat", "six"},
+		{"fto.formula.squat", "lombardi"},
+		{"fto.tmpercent.squat", "most"},
+		{"fto.tmpercent.squat", "120"},
+	}
+	for _, test := range tt {
+		v, _ := s.Values()
+		v.Set(test.key, test.value)
+		if _, err := FromValues(v); err == nil {
+			t.Error("expected an error:", test.key, test.value)
+		}
+	}
+}
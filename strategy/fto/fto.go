@@ -19,10 +19,15 @@ const (
 	// after 3 weeks. In the book the rule of thumb is 5 lbs for upper body
 	// and 10 lbs for lower body, but this make the jumps way too high for
 	// light lifters, and this facter seems to be a sweet spot for lifting in general.
+	// It is only used for a Movement without its own Increase.
 	TMIncreaseFactor float64 = 0.02
 
 	// MaxTrainingMax is the absolute maximum value that is allowed for any lift.
-	MaxTrainingMax float64 = 2000
+	MaxTrainingMax gear.Weight = 2000 * gear.Precision
+
+	// maxWeeks is how many Weeks of a Progression are calculated at once, which bounds
+	// the plate solver tables a single plan holds without holding up any other plan.
+	maxWeeks = 2
 )
 
 var (
@@ -129,6 +134,21 @@ func (d DeloadType) String() string {
 	return fmt.Sprintf("deload%v", uint8(d)+1)
 }
 
+// DeloadTypeFromString takes a string and returns a DeloadType and an error
+func DeloadTypeFromString(s string) (DeloadType, error) {
+	deloadType, ok := stringToDeloadType[s]
+	if !ok {
+		return 0, ErrInvalidDeloadType
+	}
+	return deloadType, nil
+}
+
+// Valid returns true when there is a template for the DeloadType.
+func (d DeloadType) Valid() bool {
+	_, ok := deloadTemplate[d]
+	return ok
+}
+
 // MarshalJSON is the json marshaller for DeloadType
 func (d DeloadType) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf(`"%s"`, d)), nil
@@ -140,9 +160,9 @@ func (d *DeloadType) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &dt); err != nil {
 		return err
 	}
-	deloadType, ok := stringToDeloadType[dt]
-	if !ok {
-		return ErrInvalidDeloadType
+	deloadType, err := DeloadTypeFromString(dt)
+	if err != nil {
+		return err
 	}
 	*d = deloadType
 	return nil
@@ -156,6 +176,13 @@ const (
 	FSLMULTI StrategyType = iota
 	// FSL is a StrategyType for FSL AMRAP in a single set
 	FSL
+	// BBB is a StrategyType for Boring But Big (5x10) at a percent of the training max
+	BBB
+	// SSL is a StrategyType for Second Set Last (5x5)
+	SSL
+	// TOPSETS is a StrategyType for sets of the last Working set, 5x5, 3x3 or 1x1 with
+	// as many sets as the reps prescribed for the week
+	TOPSETS
 )
 
 // StrategyTypeFromString takes a string and returns a StrategyType and an error
@@ -170,10 +197,13 @@ func StrategyTypeFromString(s string) (StrategyType, error) {
 var stringToStrategyType = map[string]StrategyType{
 	"FSL Multiple Sets": FSLMULTI,
 	"FSL":               FSL,
+	"Boring But Big":    BBB,
+	"SSL":               SSL,
+	"5x5/3x3/1x1":       TOPSETS,
 }
 
 func (s StrategyType) String() string {
-	n := []string{"FSL Multiple Sets", "FSL"}
+	n := []string{"FSL Multiple Sets", "FSL", "Boring But Big", "SSL", "5x5/3x3/1x1"}
 	if int(s) < len(n) {
 		return n[s]
 	}
@@ -198,34 +228,98 @@ func (s *StrategyType) UnmarshalJSON(b []byte) error {
 }
 
 // Movement is used to capture the needed info for a 5/3/1 movement, such as Deadlift, Overhead Press, etc.
-// It gets a Name, TrainingMax (90% of absolute 1RM) and a unit.
+// It gets a Name, TrainingMax (90% of absolute 1RM) and a unit. Bar optionally replaces the bar of the
+// gear for the Movement, such as a trap bar for deadlifts, or a dumbbell handle. Implements are fixed
+// weights, such as dumbbells or kettlebells, used instead of the gear. Increase optionally replaces
+// the TMIncreaseFactor used to increase the TrainingMax between cycles. Estimate records the 1 rep
+// max, or recent set, the TrainingMax was derived from, if any. Pair is the name of the movement
+// whose Boring But Big sets are paired with this one, see pairedLifts for the default.
 type Movement struct {
-	Name        string    `json:"name"`
-	TrainingMax float64   `json:"training_max"`
-	Unit        gear.Unit `json:"unit"`
-	Calculated  bool      `json:"calculated"`
+	Name        string       `json:"name"`
+	TrainingMax gear.Weight  `json:"training_max"`
+	Unit        gear.Unit    `json:"unit"`
+	Calculated  bool         `json:"calculated"`
+	Bar         *gear.Bar    `json:"bar,omitempty"`
+	Implements  *gear.Plates `json:"implements,omitempty"`
+	Increase    *Increase    `json:"increase,omitempty"`
+	Estimate    *Estimate    `json:"estimate,omitempty"`
+	Pair        string       `json:"pair,omitempty"`
+}
+
+// cycle returns the Movement for the nth cycle, starting from 1, with the TrainingMax
+// increased once for every cycle before it.
+func (m Movement) cycle(n uint) Movement {
+	// ensure that we never calculate anything larger than absolute max.
+	start := min(m.TrainingMax, MaxTrainingMax)
+	tm := start
+	for i := uint(1); i < n; i++ {
+		tm = min(tm+Movement{TrainingMax: tm, Increase: m.Increase}.increase(), MaxTrainingMax)
+	}
+	m.Calculated = tm != start
+	m.TrainingMax = tm
+	return m
+}
+
+// increase returns how much the TrainingMax of the Movement increases between cycles,
+// from the Increase of the Movement when it has one, otherwise the TMIncreaseFactor.
+func (m Movement) increase() gear.Weight {
+	if m.Increase != nil {
+		return m.Increase.amount(m.TrainingMax)
+	}
+	return m.TrainingMax.Mul(TMIncreaseFactor)
+}
+
+// equipment returns the Implements of the Movement, rounded the same as the gear, when
+// it has them. Otherwise it returns the gear with the Bar of the Movement, if it has one.
+func (m Movement) equipment(g gear.Gear) gear.Equipment {
+	if m.Implements != nil {
+		return gear.FixedWeights{
+			Implements: *m.Implements,
+			Unit:       g.Unit,
+			Rounding:   g.Rounding,
+			Tolerance:  g.Tolerance,
+		}
+	}
+	if m.Bar != nil {
+		g.Bar = *m.Bar
+	}
+	return g
 }
 
-func (m Movement) percentOfMax(weight float64, unit gear.Unit) (float64, error) {
+func (m Movement) percentOfMax(weight gear.Weight, unit gear.Unit) (float64, error) {
 	w, err := gear.ConvertFromTo(weight, unit, m.Unit)
-	return w / m.TrainingMax * 100, err
+	if m.TrainingMax <= 0 {
+		return 0, err
+	}
+	return float64(w) / float64(m.TrainingMax) * 100, err
 }
 
 // Set outlines how a Movement is performed, it includes a percent to calculate from Movement.TrainingMax,
 // rep count, it also gets an AMRAP (As Many Reps As Possible) bool, which indicates if a set is a "plus" round. For instance
 // a set with 5 reps simply should perform 5 reps, while a set of 5 reps + AMRAP = true, should perform
 // a minimum of 5 reps, but should attempt for As Many Reps As Possible(AMRAP). The Type is the SetType for the movement.
+// Load and Unload are the plates, per side, to add and remove from the set before, from a
+// loading plan that keeps plate changes low without promising the fewest. OverCapacity
+// is true when the set is heavier than can be loaded on the bar, and the Weight is the heaviest
+// that can be. Diagram is an inline SVG of one side of the bar loaded with the Plates. Logged
+// is the reps performed, once the set is done.
 type Set struct {
-	Movement Movement  `json:"movement"`
-	Percent  float64   `json:"percentage"`
-	Reps     uint      `json:"reps"`
-	AMRAP    bool      `json:"amrap"`
-	Type     SetType   `json:"type"`
-	Weight   float64   `json:"weight,omitempty"`
-	Plates   []float64 `json:"plates,omitempty"`
+	Movement     Movement      `json:"movement"`
+	Percent      float64       `json:"percentage"`
+	Reps         uint          `json:"reps"`
+	AMRAP        bool          `json:"amrap"`
+	Type         SetType       `json:"type"`
+	Weight       gear.Weight   `json:"weight,omitempty"`
+	Plates       []gear.Weight `json:"plates,omitempty"`
+	Load         []gear.Weight `json:"load,omitempty"`
+	Unload       []gear.Weight `json:"unload,omitempty"`
+	OverCapacity bool          `json:"over_capacity,omitempty"`
+	Diagram      template.HTML `json:"-"`
+	Logged       *uint         `json:"logged,omitempty"`
 }
 
 func (s *Set) calculate(recommendPlates bool, g gear.Gear) error {
+	e := s.Movement.equipment(g)
 	floor, err := s.Movement.floor(g)
 	if err != nil {
 		return err
@@ -238,15 +332,34 @@ func (s *Set) calculate(recommendPlates bool, g gear.Gear) error {
 	// units in .floor()
 	max, _ := gear.ConvertFromTo(s.Movement.TrainingMax, s.Movement.Unit, g.Unit)
 
-	c := max * s.Percent / 100
-	rounded, err := g.Round(c)
+	// when rounding is capped, the gear tolerance is the percent of its
+	// prescribed weight a set is allowed to go over, the same as Gear.Load.
+	c := max.Mul(s.Percent / 100)
+	limit := c.Mul(1 + g.Tolerance/100)
+	// the floor is a percent, converted between units it can land a fraction
+	// under the empty bar, which is the lightest any set can be.
+	if min, err := e.Min(); err == nil && c < min {
+		c = min
+		if limit < min {
+			limit = min
+		}
+	}
+	capacity, limited, err := e.Capacity()
+	if err != nil {
+		return err
+	}
+	if limited && c > capacity {
+		(*s).OverCapacity = true
+		c, limit = capacity, capacity
+	}
+	rounded, err := e.RoundWithin(c, limit)
 	if err != nil {
 		return err
 	}
 	(*s).Weight = rounded
 
 	if recommendPlates {
-		rec, _ := g.Recommend(c)
+		rec, _ := e.Recommend(rounded)
 		(*s).Plates = rec
 	}
 
@@ -263,9 +376,10 @@ func (s *Session) setMovement(m Movement) {
 }
 
 // floor takes gear as an argument and returns the percentage of the max as the floor based
-// on the bar.
+// on the bar of the Movement, or the gear, and any attachments on it. For implements it is the
+// lightest implement.
 func (m Movement) floor(g gear.Gear) (float64, error) {
-	min, err := g.Min()
+	min, err := m.equipment(g).Min()
 	if err != nil {
 		return 0, err
 	}
@@ -354,12 +468,69 @@ func (s *Session) addFSL() error {
 	return nil
 }
 
+// addSSL adds 5 rounds of 5 for the second working set of a Session.
+func (s *Session) addSSL() error {
+	var working []Set
+	for _, set := range *s {
+		if set.Type == Working {
+			working = append(working, set)
+		}
+	}
+	if len(working) < 2 {
+		return fmt.Errorf("no second set found matching: %v", Working)
+	}
+	f := working[1]
+	f.Type = Auxiliary
+	f.Reps = 5
+	f.AMRAP = false
+	sets := []Set{f, f, f, f, f}
+	(*s) = append((*s), sets...)
+	return nil
+}
+
+// addTopSets adds as many rounds as the reps of the last working set of a Session,
+// at the same weight and reps, such as 5x5 in a week of 5s and 3x3 in a week of 3s.
+func (s *Session) addTopSets() error {
+	l, err := s.last(Working)
+	if err != nil {
+		return err
+	}
+	l.Type = Auxiliary
+	l.AMRAP = false
+	for i := uint(0); i < l.Reps; i++ {
+		(*s) = append((*s), l)
+	}
+	return nil
+}
+
+// addBBB adds 5 rounds of 10 at a percent of the training max, of the
+// paired Movement when it isn't nil.
+func (s *Session) addBBB(percent float64, paired *Movement) error {
+	f, err := s.first(Working)
+	if err != nil {
+		return err
+	}
+	f.Type = Auxiliary
+	f.Reps = 10
+	f.AMRAP = false
+	f.Percent = percent
+	if paired != nil {
+		f.Movement = *paired
+	}
+	sets := []Set{f, f, f, f, f}
+	(*s) = append((*s), sets...)
+	return nil
+}
+
+// calculate calculates every Set in the Session. When recommending plates,
+// the plates are planned across the whole Session to keep the changes
+// between sets as low as possible.
 func (s *Session) calculate(recommendPlates bool, g gear.Gear) error {
 	l := len(*s)
 	c := make(chan worker, l)
 	for i, set := range *s {
 		go func(i int, set Set, g gear.Gear) {
-			err := set.calculate(recommendPlates, g)
+			err := set.calculate(false, g)
 			c <- worker{
 				Inc:   i,
 				Set:   set,
@@ -374,6 +545,54 @@ func (s *Session) calculate(recommendPlates bool, g gear.Gear) error {
 		}
 		(*s)[sw.Inc] = sw.Set
 	}
+	if recommendPlates {
+		return s.planLoads(g)
+	}
+	return nil
+}
+
+// planLoads sets the Plates, Load and Unload of every Set in the Session
+// from a single loading plan for each run of Sets of the same Movement, so
+// sets of a paired lift are loaded on their own equipment. Sets loaded on
+// a bar also get a Diagram of the Plates.
+func (s *Session) planLoads(g gear.Gear) error {
+	sets := *s
+	for len(sets) > 0 {
+		n := 1
+		for n < len(sets) && sets[n].Movement.Name == sets[0].Movement.Name {
+			n++
+		}
+		if err := sets[:n].planLoad(g); err != nil {
+			return err
+		}
+		sets = sets[n:]
+	}
+	return nil
+}
+
+// planLoad sets the Plates, Load and Unload of every Set in the Session from
+// a single loading plan with the equipment of the first Set.
+func (s Session) planLoad(g gear.Gear) error {
+	e := s[0].Movement.equipment(g)
+	weights := make([]gear.Weight, len(s))
+	for i, set := range s {
+		weights[i] = set.Weight
+	}
+	loads, err := e.Sequence(weights)
+	if err != nil {
+		return err
+	}
+	var previous []gear.Weight
+	for i, l := range loads {
+		c := gear.Changes(previous, l.Plates)
+		s[i].Plates = l.Plates
+		s[i].Load = c.Load
+		s[i].Unload = c.Unload
+		if bar, ok := e.(gear.Gear); ok {
+			s[i].Diagram = bar.Diagram(l.Plates)
+		}
+		previous = l.Plates
+	}
 	return nil
 }
 
@@ -447,11 +666,23 @@ func (s Session) copy() Session {
 	return sess
 }
 
-// A Week is a slice of sessions as well as a Deload boolean
+// A Week is a slice of sessions as well as a Deload boolean. Cycle is the cycle, starting
+// from 1, the Week is in or comes after, and TMTest is true for a training max test week.
+// Wave is the week of the cycle, from 0 to 2, and Block is the name of its Block.
+// TMPercent is the percent of the training max of every Movement used by the Block, and
+// Unit is the units of the Weight of every Set.
 type Week struct {
 	Sessions        []Session `json:"sessions"`
+	Cycle           uint      `json:"cycle,omitempty"`
+	Block           string    `json:"block,omitempty"`
+	Wave            int       `json:"wave"`
 	Deload          bool      `json:"deload,omitempty"`
+	TMTest          bool      `json:"tm_test,omitempty"`
 	RecommendPlates bool      `json:"recommend_plates,omitempty"`
+	TMPercent       float64   `json:"tm_percent,omitempty"`
+	Unit            gear.Unit `json:"unit"`
+
+	block Block
 }
 
 // DisplayNumber shows the week number in human readable form from index
@@ -459,10 +690,18 @@ func (w Week) DisplayNumber(n int) int {
 	return n + 1
 }
 
-func (w *Week) calculate(recommendPlates, warmup, jokersets bool, aux StrategyType, g gear.Gear) error {
+// calculate adds the warmup, joker and auxiliary sets of the Block of the Week to every
+// Session and calculates them. Boring But Big sets are left out of deload and TM test weeks.
+func (w *Week) calculate(recommendPlates bool, b bbb, g gear.Gear) error {
+	warmup, jokersets, aux := w.block.Warmup, w.block.JokerSets, w.block.Type
 	l := len(w.Sessions)
 	c := make(chan worker, l)
 	(*w).RecommendPlates = recommendPlates
+	(*w).Unit = g.Unit
+	pairs := make([]*Movement, l)
+	for i, sess := range w.Sessions {
+		pairs[i] = b.pair(sess[0].Movement, w.Sessions)
+	}
 	for i, sess := range w.Sessions {
 		go func(i int, sess Session, g gear.Gear) {
 			if warmup {
@@ -484,6 +723,14 @@ func (w *Week) calculate(recommendPlates, warmup, jokersets bool, aux StrategyTy
 					err = sess.addFSLMulti()
 				case FSL:
 					err = sess.addFSL()
+				case SSL:
+					err = sess.addSSL()
+				case TOPSETS:
+					err = sess.addTopSets()
+				case BBB:
+					if !w.Deload && !w.TMTest {
+						err = sess.addBBB(b.percent(w.Wave), pairs[i])
+					}
 				default:
 					err = errors.New("strategy type not implemented")
 				}
@@ -514,13 +761,16 @@ func (w *Week) calculate(recommendPlates, warmup, jokersets bool, aux StrategyTy
 // Progression is a slice of Weeks.
 type Progression []Week
 
-func (p *Progression) calculate(recommendPlates, warmup, jokersets bool, aux StrategyType, g gear.Gear) error {
+func (p *Progression) calculate(recommendPlates bool, b bbb, g gear.Gear) error {
 	l := len(*p)
 	c := make(chan worker, l)
+	weeks := make(chan struct{}, maxWeeks)
 
 	for i, w := range *p {
 		go func(i int, w Week, g gear.Gear) {
-			err := w.calculate(recommendPlates, warmup, jokersets, aux, g)
+			weeks <- struct{}{}
+			err := w.calculate(recommendPlates, b, g)
+			<-weeks
 			c <- worker{
 				Inc:   i,
 				Week:  w,
@@ -538,21 +788,51 @@ func (p *Progression) calculate(recommendPlates, warmup, jokersets bool, aux Str
 	return nil
 }
 
-// Strategy is a 531 struct that containers movements, gear, and strategy
+// Strategy is a 531 struct that containers movements, gear, and strategy. Profile is
+// the name of a profile from the gear.Profiles catalog, and is used instead of Gear when set.
+// Cycles is the number of 3 week cycles to plan, DefaultCycles when zero, and SeventhWeeks
+// places deload and TM test weeks between them. Without SeventhWeeks there is a deload
+// after every DeloadEvery cycles, or every other cycle when zero. Deload is the template
+// used for every deload week. BBBPercents are the percents of the training max for each
+// week of a cycle of the BBB Type, DefaultBBBPercents when empty, and BBBPaired takes the
+// BBB sets from the paired lift, such as squats on deadlift days. MainSets is how the
+// Working sets are performed, beside the supplemental sets of the Type. Blocks, when set,
+// are planned one after the other in place of the Cycles, SeventhWeeks, DeloadEvery, Type,
+// MainSets, Warmup and JokerSets of the Strategy, such as leader cycles before an anchor.
 type Strategy struct {
-	Movements       []Movement   `json:"movements"`
-	Gear            gear.Gear    `json:"gear"`
-	Type            StrategyType `json:"type"`
-	Deload          DeloadType   `json:"deload_type"`
-	Warmup          bool         `json:"warmup"`
-	JokerSets       bool         `json:"joker_sets"`
-	RecommendPlates bool         `json:"recommend_plates"`
+	Movements       []Movement    `json:"movements"`
+	Gear            gear.Gear     `json:"gear"`
+	Profile         string        `json:"profile,omitempty"`
+	Type            StrategyType  `json:"type"`
+	MainSets        MainSetMode   `json:"main_sets"`
+	Deload          DeloadType    `json:"deload_type"`
+	Cycles          uint          `json:"cycles,omitempty"`
+	DeloadEvery     uint          `json:"deload_every,omitempty"`
+	SeventhWeeks    []SeventhWeek `json:"seventh_weeks,omitempty"`
+	Warmup          bool          `json:"warmup"`
+	JokerSets       bool          `json:"joker_sets"`
+	RecommendPlates bool          `json:"recommend_plates"`
+	BBBPercents     []float64     `json:"bbb_percents,omitempty"`
+	BBBPaired       bool          `json:"bbb_paired,omitempty"`
+	Blocks          []Block       `json:"blocks,omitempty"`
 }
 
 //Plan implements a liftplan.Plan
 func (s Strategy) Plan(f liftplan.Format) ([]byte, error) {
-	p := newProgression(s.Movements, s.Deload)
-	err := p.calculate(s.RecommendPlates, s.Warmup, s.JokerSets, s.Type, s.Gear)
+	g, err := s.resolvedGear()
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.schedule()
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.bbb()
+	if err != nil {
+		return nil, err
+	}
+	p := newProgression(s.Movements, s.Deload, schedule)
+	err = p.calculate(s.RecommendPlates, b, g)
 	if err != nil {
 		return nil, err
 	}
@@ -570,9 +850,22 @@ func (s Strategy) Plan(f liftplan.Format) ([]byte, error) {
 	}
 }
 
+// resolvedGear returns the Gear of the Profile when it is set, otherwise the Gear.
+func (s Strategy) resolvedGear() (gear.Gear, error) {
+	if s.Profile == "" {
+		return s.Gear, nil
+	}
+	p, err := gear.ProfileFromName(s.Profile)
+	return p.Gear, err
+}
+
 // Values conforms to the Valuer interface and is part of the LiftPlanner interface
 func (s Strategy) Values() (url.Values, error) {
-	vals, err := gear.ToValues(s.Gear)
+	g, err := s.resolvedGear()
+	if err != nil {
+		return nil, err
+	}
+	vals, err := gear.ToValues(g)
 	if err != nil {
 		return vals, err
 	}
@@ -581,52 +874,67 @@ func (s Strategy) Values() (url.Values, error) {
 	vals.Set(namespace+".jokersets", fmt.Sprintf("%v", s.JokerSets))
 	vals.Set(namespace+".recplates", fmt.Sprintf("%v", s.RecommendPlates))
 	vals.Set(namespace+".strategy", s.Type.String())
-	// TODO: we need to make sure these movements are exported properly
-
+	vals.Set(namespace+".deload", s.Deload.String())
+	vals.Set(namespace+".main", s.MainSets.String())
+	for _, p := range s.BBBPercents {
+		vals.Add(namespace+".bbb", fmt.Sprintf("%v", p))
+	}
+	vals.Set(namespace+".bbbpaired", fmt.Sprintf("%v", s.BBBPaired))
+	if s.Cycles != 0 {
+		vals.Set(namespace+".cycles", fmt.Sprintf("%v", s.Cycles))
+	}
+	if s.DeloadEvery != 0 {
+		vals.Set(namespace+".deloadevery", fmt.Sprintf("%v", s.DeloadEvery))
+	}
+	if s.SeventhWeeks != nil {
+		vals.Set(namespace+".seventh", formatSeventhWeeks(s.SeventhWeeks))
+	}
+	blockValues(vals, s.Blocks)
+	keys := movementKeys(s.Movements)
 	for i, m := range s.Movements {
-		a, err := gear.ConvertFromTo(m.TrainingMax, m.Unit, s.Gear.Unit)
-		if err != nil {
+		if err := movementValues(vals, keys[i], m); err != nil {
 			return vals, err
 		}
-		vals.Set(namespace+fmt.Sprintf(".%v", i), fmt.Sprintf("%.2f", a))
 	}
 	return vals, nil
 }
 
-// NewProgression Generates a new 7 week progression from a set of movements
-func newProgression(movements []Movement, d DeloadType) Progression {
-	l := 7
+// newProgression generates a progression from a set of movements with a Week for every week
+// of the schedule. The training max of each movement is carried forward from cycle to cycle,
+// and the Working sets and training max of each Week follow its Block.
+func newProgression(movements []Movement, d DeloadType, schedule []week) Progression {
+	l := len(schedule)
 	p := make([]Week, l)
 	c := make(chan worker, l)
 
 	for i, w := range p {
-		go func(i int, w Week) {
-			w.Deload = i == 6
+		go func(i int, w Week, wk week) {
+			w.Cycle = wk.Cycle
+			w.Wave = wk.Wave
+			w.Block = wk.Block.Name
+			w.block = *wk.Block
+			w.TMPercent = wk.Block.TMPercent
+			if wk.Seventh != nil {
+				w.Deload = wk.Seventh.Type == DeloadWeek
+				w.TMTest = wk.Seventh.Type == TMTestWeek
+			}
 			for _, m := range movements {
 				var sess Session
-				tmIncrease := m.TrainingMax * TMIncreaseFactor
-				if w.Deload {
-					m.TrainingMax = m.TrainingMax + tmIncrease
-					m.Calculated = true
+				m = wk.Block.trainingMax(m.cycle(wk.Cycle))
+				switch {
+				case w.Deload:
 					sess = deloadTemplate[d].copy()
-				} else {
-					item := i
-					if item > 2 {
-						item -= 3
-						m.TrainingMax = m.TrainingMax + tmIncrease
-						m.Calculated = true
-					}
-					sess = workingSetTemplate[item].copy()
-				}
-				// ensure that we never calculate anything larger than absolute max.
-				if m.TrainingMax > MaxTrainingMax {
-					m.TrainingMax = MaxTrainingMax
+				case w.TMTest:
+					sess = tmTestTemplate.copy()
+				default:
+					sess = workingSetTemplate[wk.Wave].copy()
+					sess.setMainSets(wk.Block.MainSets)
 				}
 				sess.setMovement(m)
 				w.Sessions = append(w.Sessions, sess)
 			}
 			c <- worker{Inc: i, Week: w}
-		}(i, w)
+		}(i, w, schedule[i])
 	}
 	for i := 0; i < l; i++ {
 		ww := <-c
@@ -15,12 +15,12 @@ func TestStrategy(t *testing.T) {
 	t.Run("Plan", func(t *testing.T) {
 		m1 := Movement{
 			Name:        "over-head press",
-			TrainingMax: 175,
+			TrainingMax: 175 * gear.Precision,
 			Unit:        gear.LBS,
 		}
 		m2 := Movement{
 			Name:        "squat",
-			TrainingMax: 4000,
+			TrainingMax: 4000 * gear.Precision,
 			Unit:        gear.LBS,
 		}
 		s1 := Strategy{
@@ -42,8 +42,22 @@ func TestStrategy(t *testing.T) {
 		if _, err := s1.Plan(liftplan.JSON); err != nil {
 			t.Error(err)
 		}
-		if _, err := s2.Plan(liftplan.HTML); err != nil {
+		if b, err := s2.Plan(liftplan.HTML); err != nil {
 			t.Error(err)
+		} else if !bytes.Contains(b, []byte(`<svg class="diagram"`)) {
+			t.Error("expected a bar diagram in the plan")
+		}
+		s3 := Strategy{
+			Movements: []Movement{m1},
+			Profile:   gear.HomeProfile.Name,
+			Type:      FSL,
+		}
+		if _, err := s3.Plan(liftplan.JSON); err != nil {
+			t.Error(err)
+		}
+		s3.Profile = "moon base"
+		if _, err := s3.Plan(liftplan.JSON); err != gear.ErrProfileNotFound {
+			t.Error("unexpected error:", err)
 		}
 	})
 	t.Run("Values", func(t *testing.T) {
@@ -155,6 +169,115 @@ func TestSesion(t *testing.T) {
 			}
 		}
 	})
+	t.Run("addSSL", func(t *testing.T) {
+		t.Parallel()
+		sess := Session{
+			{Type: Working, Percent: 65, Reps: 5},
+			{Type: Working, Percent: 75, Reps: 5},
+			{Type: Working, Percent: 85, Reps: 5, AMRAP: true},
+		}
+		if err := sess.addSSL(); err != nil {
+			t.Fatal(err)
+		}
+		if len(sess) != 8 {
+			t.Fatal("unexpected number of sets:", len(sess))
+		}
+		for _, s := range sess[3:] {
+			if s.Type != Auxiliary || s.Percent != 75 || s.Reps != 5 || s.AMRAP {
+				t.Error("unexpected ssl set:", s)
+			}
+		}
+		if err := (&Session{{Type: Working}}).addSSL(); err == nil {
+			t.Error("expected an error without a second working set")
+		}
+	})
+	t.Run("addTopSets", func(t *testing.T) {
+		t.Parallel()
+		sess := Session{
+			{Type: Working, Percent: 70, Reps: 3},
+			{Type: Working, Percent: 80, Reps: 3},
+			{Type: Working, Percent: 90, Reps: 3, AMRAP: true},
+		}
+		if err := sess.addTopSets(); err != nil {
+			t.Fatal(err)
+		}
+		if len(sess) != 6 {
+			t.Fatal("unexpected number of sets:", len(sess))
+		}
+		for _, s := range sess[3:] {
+			if s.Type != Auxiliary || s.Percent != 90 || s.Reps != 3 || s.AMRAP {
+				t.Error("unexpected top set:", s)
+			}
+		}
+		if err := (&Session{}).addTopSets(); err == nil {
+			t.Error("expected an error without a working set")
+		}
+	})
+	t.Run("addBBB", func(t *testing.T) {
+		t.Parallel()
+		squat := Movement{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS}
+		deadlift := Movement{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS}
+
+		sess := Session{Set{Movement: deadlift, Percent: 65, Reps: 5, Type: Working}}
+		if err := sess.addBBB(60, nil); err != nil {
+			t.Fatal(err)
+		}
+		paired := Session{Set{Movement: deadlift, Percent: 65, Reps: 5, Type: Working}}
+		if err := paired.addBBB(50, &squat); err != nil {
+			t.Fatal(err)
+		}
+		if len(sess) != 6 || len(paired) != 6 {
+			t.Fatal("unexpected number of sets:", len(sess), len(paired))
+		}
+		for i := 1; i < 6; i++ {
+			if s := sess[i]; s.Type != Auxiliary || s.Reps != 10 || s.AMRAP || s.Percent != 60 || s.Movement.Name != "deadlift" {
+				t.Error("unexpected bbb set:", i, s)
+			}
+			if s := paired[i]; s.Percent != 50 || s.Movement.Name != "squat" {
+				t.Error("unexpected paired bbb set:", i, s)
+			}
+		}
+		if err := (&Session{}).addBBB(50, nil); err == nil {
+			t.Error("expected an error without a working set")
+		}
+
+		if err := paired.calculate(true, gear.Default(gear.LBS)); err != nil {
+			t.Fatal(err)
+		}
+		if paired[0].Weight != 260*gear.Precision || paired[1].Weight != 150*gear.Precision {
+			t.Error("unexpected weights:", paired[0].Weight, paired[1].Weight)
+		}
+		if c := gear.Changes(nil, paired[1].Plates); len(paired[1].Load) != len(c.Load) {
+			t.Error("expected the paired lift to be loaded from an empty bar:", paired[1].Load)
+		}
+	})
+	t.Run("planLoads", func(t *testing.T) {
+		t.Parallel()
+
+		m := Movement{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS}
+		sess := Session{}
+		for _, p := range []float64{40, 50, 60, 65, 75, 85, 65} {
+			sess = append(sess, Set{Movement: m, Percent: p, Reps: 5, Type: Working})
+		}
+		if err := sess.calculate(true, gear.Default(gear.LBS)); err != nil {
+			t.Fatal(err)
+		}
+		var bar, single []gear.Weight
+		planned, greedy := 0, 0
+		for i, set := range sess {
+			c := gear.Changes(bar, set.Plates)
+			if !weightsEqual(c.Load, set.Load) || !weightsEqual(c.Unload, set.Unload) {
+				t.Error("unexpected load/unload for set", i, set.Load, set.Unload)
+			}
+			rec, _ := gear.Default(gear.LBS).Recommend(set.Weight)
+			planned += c.Count()
+			greedy += gear.Changes(single, rec).Count()
+			bar, single = set.Plates, rec
+		}
+		if planned > greedy {
+			t.Error("planned session moves more plates than planning each set:", planned, greedy)
+		}
+	})
 	t.Run("SetTypeIndex", func(t *testing.T) {
 		t.Parallel()
 
@@ -317,6 +440,24 @@ func TestDeloadType(t *testing.T) {
 			}
 		}
 	})
+	t.Run("FromString", func(t *testing.T) {
+		t.Parallel()
+		if d, err := DeloadTypeFromString("deload4"); d != Deload4 || err != nil {
+			t.Error("unexpected deload type:", d, err)
+		}
+		if _, err := DeloadTypeFromString("deload6"); err != ErrInvalidDeloadType {
+			t.Error("error failed to return for 'deload6'")
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		if !Deload5.Valid() {
+			t.Error("expected Deload5 to be valid")
+		}
+		if DeloadType(20).Valid() {
+			t.Error("expected DeloadType(20) to be invalid")
+		}
+	})
 }
 
 func TestStrategyType(t *testing.T) {
@@ -379,6 +520,15 @@ func TestStrategyTypeFromString(t *testing.T) {
 	if g != StrategyType(0) {
 		t.Error("mismatch", g, StrategyType(0), errors.New("json: cannot unmarshal bool into Go value of type string"))
 	}
+	if b, err := StrategyTypeFromString("Boring But Big"); b != BBB || err != nil {
+		t.Error("unexpected strategy type:", b, err)
+	}
+	if b, err := StrategyTypeFromString(SSL.String()); b != SSL || err != nil {
+		t.Error("unexpected strategy type:", b, err)
+	}
+	if b, err := StrategyTypeFromString(TOPSETS.String()); b != TOPSETS || err != nil {
+		t.Error("unexpected strategy type:", b, err)
+	}
 	{
 		_, err := StrategyTypeFromString("blah")
 		if err != ErrInvalidStrategyType {
@@ -394,19 +544,19 @@ func TestSet(t *testing.T) {
 
 		m1 := Movement{
 			Name:        "over-head press",
-			TrainingMax: 175,
+			TrainingMax: 175 * gear.Precision,
 			Unit:        gear.LBS,
 		}
 
 		mBadUnit := Movement{
 			Name:        "over-head press",
-			TrainingMax: 175,
+			TrainingMax: 175 * gear.Precision,
 			Unit:        gear.Unit(5),
 		}
 
 		tooLow := Movement{
 			Name:        "over-head press",
-			TrainingMax: 5,
+			TrainingMax: 5 * gear.Precision,
 			Unit:        gear.LBS,
 		}
 
@@ -428,7 +578,7 @@ func TestSet(t *testing.T) {
 		badBarGear.Bar.Unit = gear.Unit(5)
 
 		badPlatesGear := gear.Default(gear.LBS)
-		badPlatesGear.Plates.Weights = []float64{}
+		badPlatesGear.Plates.Weights = []gear.Weight{}
 
 		tt := []struct {
 			set  Set
@@ -451,4 +601,226 @@ func TestSet(t *testing.T) {
 			}
 		}
 	})
+	t.Run("rounding", func(t *testing.T) {
+		t.Parallel()
+
+		g := func(r gear.Rounding, tolerance float64) gear.Gear {
+			d := gear.Default(gear.LBS)
+			d.Rounding = r
+			d.Tolerance = tolerance
+			return d
+		}
+		m := Movement{Name: "bench press", TrainingMax: 203 * gear.Precision, Unit: gear.LBS}
+
+		tt := []struct {
+			gear     gear.Gear
+			expected float64
+		}{
+			{g(gear.Floor, 0), 170},
+			{g(gear.Nearest, 0), 175},
+			{g(gear.Ceiling, 0), 175},
+			{g(gear.Capped, 1), 170},
+			{g(gear.Capped, 2), 175},
+			// the tolerance is a percent of the 172.55 prescribed, not of the training max.
+			{g(gear.Capped, 1.4), 170},
+		}
+		for _, test := range tt {
+			s := Set{Movement: m, Percent: 85}
+			if err := s.calculate(true, test.gear); err != nil {
+				t.Error(err)
+			}
+			if s.Weight != gear.NewWeight(test.expected) {
+				t.Error("unexpected weight:", test.gear.Rounding, s.Weight, test.expected)
+			}
+		}
+	})
+	t.Run("attachments", func(t *testing.T) {
+		t.Parallel()
+
+		g := gear.Default(gear.KG)
+		g.Attachments = []gear.Attachment{gear.CompetitionCollarsKG}
+		m := Movement{Name: "squat", TrainingMax: 100 * gear.Precision, Unit: gear.KG}
+
+		tt := []struct {
+			percent  float64
+			expected Set
+		}{
+			{10, Set{Percent: 25, Weight: 25 * gear.Precision}},
+			{50, Set{Percent: 50, Weight: 50 * gear.Precision, Plates: weights(2.5, 10)}},
+		}
+		for _, test := range tt {
+			s := Set{Movement: m, Percent: test.percent}
+			if err := s.calculate(true, g); err != nil {
+				t.Error(err)
+			}
+			if s.Percent != test.expected.Percent || s.Weight != test.expected.Weight ||
+				!weightsEqual(s.Plates, test.expected.Plates) {
+				t.Error("unexpected set:", s, test.expected)
+			}
+		}
+	})
+	t.Run("bar", func(t *testing.T) {
+		t.Parallel()
+
+		trap := gear.TrapBar
+		m := Movement{Name: "deadlift", TrainingMax: 300 * gear.Precision, Unit: gear.LBS, Bar: &trap}
+
+		tt := []struct {
+			percent  float64
+			expected Set
+		}{
+			{10, Set{Percent: 20, Weight: 60 * gear.Precision}},
+			{50, Set{Percent: 50, Weight: 150 * gear.Precision, Plates: weights(45)}},
+		}
+		for _, test := range tt {
+			s := Set{Movement: m, Percent: test.percent}
+			if err := s.calculate(true, gear.Default(gear.LBS)); err != nil {
+				t.Error(err)
+			}
+			if s.Percent != test.expected.Percent || s.Weight != test.expected.Weight ||
+				!weightsEqual(s.Plates, test.expected.Plates) {
+				t.Error("unexpected set:", s, test.expected)
+			}
+		}
+	})
+	t.Run("mixed units", func(t *testing.T) {
+		t.Parallel()
+		// floors converted between units used to land a fraction under the bar.
+		tt := []struct {
+			unit  gear.Unit
+			gear  gear.Gear
+			start gear.Weight
+			end   gear.Weight
+		}{
+			{gear.LBS, gear.Default(gear.KG), 45 * gear.Precision, 300 * gear.Precision},
+			{gear.KG, gear.Default(gear.LBS), 20 * gear.Precision, 150 * gear.Precision},
+			{gear.STONE, gear.Default(gear.KG), 5 * gear.Precision, 110 * gear.Precision},
+		}
+		for _, test := range tt {
+			for tm := test.start; tm <= test.end; tm += gear.Precision / 2 {
+				s := Strategy{
+					Movements: []Movement{{Name: "squat", TrainingMax: tm, Unit: test.unit}},
+					Gear:      test.gear,
+					Type:      FSL,
+					Warmup:    true,
+					JokerSets: true,
+					Cycles:    1,
+				}
+				if _, err := s.Plan(liftplan.JSON); err != nil {
+					t.Error("unexpected error:", test.unit, tm, err)
+				}
+			}
+		}
+	})
+	t.Run("capacity", func(t *testing.T) {
+		t.Parallel()
+
+		g := gear.Default(gear.LBS)
+		g.Plates = gear.Plates{Weights: weights(2.5, 10, 45), Unit: gear.LBS}
+		g.Plates.SetCount(45*gear.Precision, 4)
+		g.Plates.SetCount(10*gear.Precision, 2)
+		g.Plates.SetCount(gear.NewWeight(2.5), 2)
+		m := Movement{Name: "deadlift", TrainingMax: 500 * gear.Precision, Unit: gear.LBS}
+
+		tt := []struct {
+			percent  float64
+			expected Set
+		}{
+			{50, Set{Weight: 250 * gear.Precision, Plates: weights(2.5, 10, 45, 45)}},
+			{90, Set{Weight: 250 * gear.Precision, Plates: weights(2.5, 10, 45, 45), OverCapacity: true}},
+		}
+		for _, test := range tt {
+			s := Set{Movement: m, Percent: test.percent}
+			if err := s.calculate(true, g); err != nil {
+				t.Error(err)
+			}
+			if s.Weight != test.expected.Weight || s.OverCapacity != test.expected.OverCapacity ||
+				!weightsEqual(s.Plates, test.expected.Plates) {
+				t.Error("unexpected set:", s, test.expected)
+			}
+		}
+	})
+	t.Run("implements", func(t *testing.T) {
+		t.Parallel()
+
+		dumbbells := gear.DefaultDumbbellsLBS
+		handle := gear.DumbbellHandleLBS
+		fixed := Movement{Name: "dumbbell press", TrainingMax: 80 * gear.Precision, Unit: gear.LBS, Implements: &dumbbells}
+		loadable := Movement{Name: "dumbbell press", TrainingMax: 80 * gear.Precision, Unit: gear.LBS, Bar: &handle}
+
+		tt := []struct {
+			set      Set
+			expected Set
+		}{
+			{Set{Movement: fixed, Percent: 65}, Set{Percent: 65, Weight: 50 * gear.Precision}},
+			{Set{Movement: fixed, Percent: 5}, Set{Percent: 6.25, Weight: 5 * gear.Precision}},
+			{Set{Movement: fixed, Percent: 150}, Set{Percent: 150, Weight: 100 * gear.Precision, OverCapacity: true}},
+			{Set{Movement: loadable, Percent: 65}, Set{Percent: 65, Weight: 50 * gear.Precision, Plates: weights(2.5, 10, 10)}},
+		}
+		for _, test := range tt {
+			s := test.set
+			if err := s.calculate(true, gear.Default(gear.LBS)); err != nil {
+				t.Error(err)
+			}
+			if s.Percent != test.expected.Percent || s.Weight != test.expected.Weight ||
+				s.OverCapacity != test.expected.OverCapacity || !weightsEqual(s.Plates, test.expected.Plates) {
+				t.Error("unexpected set:", s, test.expected)
+			}
+		}
+	})
+}
+
+// weights takes a float64 number of units for each weight and returns the closest Weights.
+func weights(fs ...float64) []gear.Weight {
+	w := make([]gear.Weight, len(fs))
+	for i, f := range fs {
+		w[i] = gear.NewWeight(f)
+	}
+	return w
+}
+
+func weightsEqual(a, b []gear.Weight) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPlanChangePlates(t *testing.T) {
+	t.Parallel()
+	// every change plate the gear form offers, in the other unit of the gear.
+	tt := []struct {
+		unit, extra gear.Unit
+		plates      []float64
+		tm          gear.Weight
+	}{
+		{gear.LBS, gear.KG, []float64{0.25, 0.5, 1, 1.25, 2.5}, 450 * gear.Precision},
+		{gear.KG, gear.LBS, []float64{1.25, 2.5, 5}, 200 * gear.Precision},
+	}
+	for _, test := range tt {
+		for _, p := range test.plates {
+			g := gear.Default(test.unit)
+			g.Extra = []gear.Plates{{Weights: []gear.Weight{gear.NewWeight(p)}, Unit: test.extra}}
+			var movements []Movement
+			for _, name := range DefaultMovements {
+				movements = append(movements, Movement{Name: name, TrainingMax: test.tm, Unit: test.unit})
+			}
+			s := Strategy{
+				Movements:       movements,
+				Gear:            g,
+				Type:            FSL,
+				Warmup:          true,
+				JokerSets:       true,
+				RecommendPlates: true,
+			}
+			if _, err := s.Plan(liftplan.JSON); err != nil {
+				t.Error("unexpected error:", test.unit, p, test.extra, err)
+			}
+		}
+	}
 }
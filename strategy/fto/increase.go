@@ -0,0 +1,107 @@
+package fto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+var (
+	// ErrInvalidIncreaseType represents an invalid IncreaseType
+	ErrInvalidIncreaseType = errors.New("invalid IncreaseType")
+	// ErrInvalidIncrease is returned when a Percent or Fixed Increase isn't more than zero.
+	ErrInvalidIncrease = errors.New("invalid increase")
+)
+
+// IncreaseType is an ENUM type for the ways a training max is increased between cycles.
+type IncreaseType uint
+
+const (
+	// PercentIncrease increases the training max by a percent of itself.
+	PercentIncrease IncreaseType = iota
+	// FixedIncrease increases the training max by a fixed weight, such as the
+	// 5 LBS for upper body and 10 LBS for lower body from the book.
+	FixedIncrease
+	// NoIncrease keeps the same training max.
+	NoIncrease
+)
+
+var stringToIncreaseType = map[string]IncreaseType{
+	"percent": PercentIncrease,
+	"fixed":   FixedIncrease,
+	"none":    NoIncrease,
+}
+
+// IncreaseTypeFromString takes a string and returns an IncreaseType and an error
+func IncreaseTypeFromString(s string) (IncreaseType, error) {
+	increaseType, ok := stringToIncreaseType[s]
+	if !ok {
+		return 0, ErrInvalidIncreaseType
+	}
+	return increaseType, nil
+}
+
+// String is the string representation of an increase type
+func (i IncreaseType) String() string {
+	n := []string{"percent", "fixed", "none"}
+	if int(i) < len(n) {
+		return n[i]
+	}
+	return ""
+}
+
+// MarshalJSON is the json marshaller for IncreaseType
+func (i IncreaseType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%v"`, i.String())), nil
+}
+
+// UnmarshalJSON is the json unmarshaller for IncreaseType
+func (i *IncreaseType) UnmarshalJSON(b []byte) error {
+	var it string
+	if err := json.Unmarshal(b, &it); err != nil {
+		return err
+	}
+	increaseType, err := IncreaseTypeFromString(it)
+	*i = increaseType
+	return err
+}
+
+// Increase is how the training max of a Movement increases between cycles. Percent is
+// used by a PercentIncrease, and Weight, in the units of the Movement, by a FixedIncrease.
+type Increase struct {
+	Type    IncreaseType `json:"type"`
+	Percent float64      `json:"percent,omitempty"`
+	Weight  gear.Weight  `json:"weight,omitempty"`
+}
+
+// Valid checks that the Type is valid and that a Percent or Fixed Increase is more than zero.
+func (i Increase) Valid() error {
+	switch i.Type {
+	case PercentIncrease:
+		if i.Percent <= 0 {
+			return ErrInvalidIncrease
+		}
+	case FixedIncrease:
+		if i.Weight <= 0 {
+			return ErrInvalidIncrease
+		}
+	case NoIncrease:
+	default:
+		return ErrInvalidIncreaseType
+	}
+	return nil
+}
+
+// amount returns how much a training max increases.
+func (i Increase) amount(tm gear.Weight) gear.Weight {
+	switch i.Type {
+	case PercentIncrease:
+		return tm.Mul(i.Percent / 100)
+	case FixedIncrease:
+		return i.Weight
+	default:
+		return 0
+	}
+}
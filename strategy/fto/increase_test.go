@@ -0,0 +1,112 @@
+package fto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestIncreaseType(t *testing.T) {
+	t.Parallel()
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+		if FixedIncrease.String() != "fixed" {
+			t.Error(FixedIncrease.String(), "!= fixed")
+		}
+		if b := IncreaseType(55); b.String() != "" {
+			t.Error(b.String(), "!= ''")
+		}
+	})
+	t.Run("MarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		b, _ := NoIncrease.MarshalJSON()
+		if !bytes.Equal(b, []byte(`"none"`)) {
+			t.Error(string(b), "!=", `"none"`)
+		}
+	})
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		t.Parallel()
+		tt := []struct {
+			input    []byte
+			expected IncreaseType
+			err      bool
+		}{
+			{[]byte(`"percent"`), PercentIncrease, false},
+			{[]byte(`"fixed"`), FixedIncrease, false},
+			{[]byte(`"weekly"`), 0, true},
+			{[]byte(`false`), 0, true},
+		}
+		for _, test := range tt {
+			var i IncreaseType
+			err := i.UnmarshalJSON(test.input)
+			if (err != nil) != test.err || (err == nil && i != test.expected) {
+				t.Error("unexpected increase type:", string(test.input), i, err)
+			}
+		}
+	})
+	t.Run("FromString", func(t *testing.T) {
+		t.Parallel()
+		if i, err := IncreaseTypeFromString("none"); i != NoIncrease || err != nil {
+			t.Error("unexpected increase type:", i, err)
+		}
+		if _, err := IncreaseTypeFromString("blah"); err != ErrInvalidIncreaseType {
+			t.Error("error failed to return for 'blah'")
+		}
+	})
+}
+
+func TestIncrease(t *testing.T) {
+	t.Parallel()
+	tm := 200 * gear.Precision
+	tt := []struct {
+		increase Increase
+		amount   gear.Weight
+		err      error
+	}{
+		{Increase{Type: PercentIncrease, Percent: 5}, 10 * gear.Precision, nil},
+		{Increase{Type: FixedIncrease, Weight: 10 * gear.Precision}, 10 * gear.Precision, nil},
+		{Increase{Type: NoIncrease}, 0, nil},
+		{Increase{Type: PercentIncrease}, 0, ErrInvalidIncrease},
+		{Increase{Type: FixedIncrease, Weight: -5 * gear.Precision}, -5 * gear.Precision, ErrInvalidIncrease},
+		{Increase{Type: IncreaseType(55)}, 0, ErrInvalidIncreaseType},
+	}
+	for i, test := range tt {
+		if err := test.increase.Valid(); err != test.err {
+			t.Error("unexpected error:", i, err)
+		}
+		if a := test.increase.amount(tm); a != test.amount {
+			t.Error("unexpected amount:", i, a, test.amount)
+		}
+	}
+}
+
+func TestMovementIncrease(t *testing.T) {
+	t.Parallel()
+	m := Movement{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS}
+	fixed := m
+	fixed.Increase = &Increase{Type: FixedIncrease, Weight: 5 * gear.Precision}
+	none := m
+	none.Increase = &Increase{Type: NoIncrease}
+
+	tt := []struct {
+		movement   Movement
+		tm         gear.Weight
+		calculated bool
+	}{
+		{m, 204 * gear.Precision, true},
+		{fixed, 205 * gear.Precision, true},
+		{none, 200 * gear.Precision, false},
+	}
+	schedule, _ := Strategy{}.schedule()
+	for i, test := range tt {
+		p := newProgression([]Movement{test.movement}, Deload1, schedule)
+		first, last := p[0].Sessions[0][0].Movement, p[len(p)-1].Sessions[0][0].Movement
+		if first.TrainingMax != test.movement.TrainingMax || first.Calculated {
+			t.Error("unexpected first week:", i, first)
+		}
+		if last.TrainingMax != test.tm || last.Calculated != test.calculated {
+			t.Error("unexpected deload week:", i, last)
+		}
+	}
+}
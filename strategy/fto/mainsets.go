@@ -0,0 +1,84 @@
+package fto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidMainSetMode represents an invalid MainSetMode
+	ErrInvalidMainSetMode = errors.New("invalid MainSetMode")
+)
+
+// MainSetMode is an ENUM type for the ways the Working sets of a cycle are performed.
+type MainSetMode uint
+
+const (
+	// PRSets performs the last Working set As Many Reps As Possible.
+	PRSets MainSetMode = iota
+	// FivesPro performs every Working set for 5 reps, without any AMRAP sets.
+	FivesPro
+	// MinimumReps performs every Working set for the prescribed reps, without any AMRAP sets.
+	MinimumReps
+)
+
+var stringToMainSetMode = map[string]MainSetMode{
+	"PR Sets":      PRSets,
+	"5's PRO":      FivesPro,
+	"Minimum Reps": MinimumReps,
+}
+
+// MainSetModeFromString takes a string and returns a MainSetMode and an error
+func MainSetModeFromString(s string) (MainSetMode, error) {
+	mode, ok := stringToMainSetMode[s]
+	if !ok {
+		return 0, ErrInvalidMainSetMode
+	}
+	return mode, nil
+}
+
+func (m MainSetMode) String() string {
+	n := []string{"PR Sets", "5's PRO", "Minimum Reps"}
+	if int(m) < len(n) {
+		return n[m]
+	}
+	return ""
+}
+
+// Valid returns true for a known MainSetMode.
+func (m MainSetMode) Valid() bool {
+	return m.String() != ""
+}
+
+// MarshalJSON is the json marshaller for MainSetMode
+func (m MainSetMode) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%v"`, m.String())), nil
+}
+
+// UnmarshalJSON is the json unmarshaller for MainSetMode
+func (m *MainSetMode) UnmarshalJSON(b []byte) error {
+	var ms string
+	if err := json.Unmarshal(b, &ms); err != nil {
+		return err
+	}
+	mode, err := MainSetModeFromString(ms)
+	*m = mode
+	return err
+}
+
+// setMainSets changes the Working sets of a Session to be performed by the MainSetMode.
+func (s *Session) setMainSets(m MainSetMode) {
+	for i := range *s {
+		if (*s)[i].Type != Working {
+			continue
+		}
+		switch m {
+		case FivesPro:
+			(*s)[i].Reps = 5
+			(*s)[i].AMRAP = false
+		case MinimumReps:
+			(*s)[i].AMRAP = false
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package fto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestMainSetMode(t *testing.T) {
+	t.Parallel()
+	for _, m := range []MainSetMode{PRSets, FivesPro, MinimumReps} {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var o MainSetMode
+		if err := json.Unmarshal(b, &o); err != nil || o != m {
+			t.Error("unexpected round trip:", m, o, err)
+		}
+		if !m.Valid() {
+			t.Error("expected a valid mode:", m)
+		}
+	}
+	if _, err := MainSetModeFromString("blah"); err != ErrInvalidMainSetMode {
+		t.Error("expected an invalid mode:", err)
+	}
+	if MainSetMode(10).Valid() {
+		t.Error("expected an invalid mode")
+	}
+}
+
+func TestSessionSetMainSets(t *testing.T) {
+	t.Parallel()
+	sess := func() Session {
+		return Session{
+			{Type: Warmup, Reps: 5},
+			{Type: Working, Percent: 75, Reps: 3},
+			{Type: Working, Percent: 85, Reps: 3},
+			{Type: Working, Percent: 95, Reps: 1, AMRAP: true},
+		}
+	}
+	tt := []struct {
+		mode  MainSetMode
+		reps  []uint
+		amrap bool
+	}{
+		{PRSets, []uint{5, 3, 3, 1}, true},
+		{FivesPro, []uint{5, 5, 5, 5}, false},
+		{MinimumReps, []uint{5, 3, 3, 1}, false},
+	}
+	for _, test := range tt {
+		s := sess()
+		s.setMainSets(test.mode)
+		for i, set := range s {
+			if set.Reps != test.reps[i] {
+				t.Error("unexpected reps:", test.mode, i, set.Reps)
+			}
+		}
+		if s[3].AMRAP != test.amrap {
+			t.Error("unexpected amrap:", test.mode, s[3].AMRAP)
+		}
+	}
+}
+
+func TestStrategyMainSets(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS}},
+		Gear:      gear.Default(gear.LBS),
+		Type:      TOPSETS,
+		MainSets:  FivesPro,
+	}
+	b, err := s.Plan(liftplan.JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p Progression
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range p {
+		for _, sess := range w.Sessions {
+			for _, set := range sess {
+				if set.Type == Working && (set.Reps != 5 || set.AMRAP) {
+					t.Error("expected 5's PRO working sets:", i, set)
+				}
+			}
+		}
+	}
+
+	s.MainSets = MainSetMode(10)
+	if _, err := s.Plan(liftplan.JSON); err != ErrInvalidMainSetMode {
+		t.Error("expected an invalid mode:", err)
+	}
+}
@@ -0,0 +1,231 @@
+package fto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+// DefaultMovements are the names of the movements of the form, and of values from before
+// movements were listed in fto.movement.
+var DefaultMovements = []string{"deadlift", "bench press", "overhead press", "squat"}
+
+// movementKey returns the key of the values of a Movement from its name, such as
+// front-squat for "Front Squat".
+func movementKey(name string) string {
+	k := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, name)
+	for strings.Contains(k, "--") {
+		k = strings.ReplaceAll(k, "--", "-")
+	}
+	k = strings.Trim(k, "-")
+	if k == "" {
+		return "movement"
+	}
+	return k
+}
+
+// movementKeys returns a unique key for every Movement, numbering the keys of movements
+// with the same name, such as squat and squat-2.
+func movementKeys(movements []Movement) []string {
+	keys := make([]string, len(movements))
+	seen := map[string]bool{}
+	for i, m := range movements {
+		k := movementKey(m.Name)
+		for n := 2; seen[k]; n++ {
+			k = fmt.Sprintf("%v-%v", movementKey(m.Name), n)
+		}
+		seen[k] = true
+		keys[i] = k
+	}
+	return keys
+}
+
+// movementValues adds a Movement to the values under its key. Every weight is in the
+// units of the Movement.
+func movementValues(vals url.Values, key string, m Movement) error {
+	if !m.Unit.Valid() {
+		return gear.ErrInvalidUnit
+	}
+	vals.Add(namespace+".movement", key)
+	vals.Set(namespace+".name."+key, m.Name)
+	vals.Set(namespace+".unit."+key, m.Unit.String())
+	vals.Set(namespace+".tm."+key, m.TrainingMax.String())
+	if m.Bar != nil {
+		barValues(vals, key, *m.Bar)
+	}
+	if m.Pair != "" {
+		vals.Set(namespace+".pair."+key, m.Pair)
+	}
+	if m.Estimate != nil {
+		estimateValues(vals, key, *m.Estimate)
+	}
+	if m.Increase != nil {
+		vals.Set(namespace+".increase."+key, m.Increase.Type.String())
+		switch m.Increase.Type {
+		case PercentIncrease:
+			vals.Set(namespace+".increaseby."+key, fmt.Sprintf("%v", m.Increase.Percent))
+		case FixedIncrease:
+			vals.Set(namespace+".increaseby."+key, m.Increase.Weight.String())
+		}
+	}
+	if m.Implements != nil {
+		for _, w := range m.Implements.Weights {
+			c, err := gear.ConvertFromTo(w, m.Implements.Unit, m.Unit)
+			if err != nil {
+				return err
+			}
+			vals.Add(namespace+".implement."+key, c.String())
+		}
+	}
+	return nil
+}
+
+// movementsFromValues returns the movements of the values in the order of fto.movement,
+// leaving out any with an empty name, such as unused rows of the form. A Movement without
+// a name in the values is named by its key, and without a unit is in the units of the gear.
+// Values without fto.movement are the DefaultMovements, with keys 0 to 3 and the training
+// max at fto.N.
+func movementsFromValues(v url.Values, g gear.Gear) ([]Movement, error) {
+	keys, legacy := v[namespace+".movement"], false
+	if len(keys) == 0 {
+		legacy = true
+		for i := range DefaultMovements {
+			keys = append(keys, fmt.Sprintf("%v", i))
+		}
+	}
+	var movements []Movement
+	seen := map[string]bool{}
+	for i, key := range keys {
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate movement %v", key)
+		}
+		seen[key] = true
+
+		m := Movement{Name: key, Unit: g.Unit}
+		if legacy {
+			m.Name = DefaultMovements[i]
+		} else if n, ok := v[namespace+".name."+key]; ok {
+			if n[0] == "" {
+				continue
+			}
+			m.Name = n[0]
+		}
+		if u := v.Get(namespace + ".unit." + key); u != "" {
+			unit, err := gear.UnitFromString(u)
+			if err != nil {
+				return nil, err
+			}
+			m.Unit = unit
+		}
+
+		e, ok, err := estimateFromValues(v, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.estimate(e)
+		} else {
+			k := namespace + ".tm." + key
+			if legacy {
+				k = namespace + "." + key
+			}
+			x, ok := v[k]
+			if !ok {
+				return nil, fmt.Errorf("movement %v not found", k)
+			}
+			tm, err := gear.ParseWeight(x[0])
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert %v to weight", x[0])
+			}
+			m.TrainingMax = tm
+		}
+		bar, ok, err := barFromValues(v, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.Bar = &bar
+		}
+		m.Pair = v.Get(namespace + ".pair." + key)
+		if implements, ok := v[namespace+".implement."+key]; ok {
+			p := gear.Plates{Unit: m.Unit}
+			for _, w := range implements {
+				f, err := gear.ParseWeight(w)
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert %v to weight", w)
+				}
+				p.Add(f)
+			}
+			m.Implements = &p
+		}
+		if t, ok := v[namespace+".increase."+key]; ok && t[0] != "" {
+			inc, err := increaseFromValues(t[0], v.Get(namespace+".increaseby."+key))
+			if err != nil {
+				return nil, err
+			}
+			m.Increase = &inc
+		}
+		movements = append(movements, m)
+	}
+	return movements, nil
+}
+
+// barValues adds the Bar of the movement of the key, by name when it is in the Bars
+// catalog and otherwise by its weight, unit, sleeve and name.
+func barValues(vals url.Values, key string, b gear.Bar) {
+	if c, err := gear.BarFromName(b.Name); err == nil && c.Equals(b) {
+		vals.Set(namespace+".bar."+key, b.Name)
+		return
+	}
+	if b.Name != "" {
+		vals.Set(namespace+".barname."+key, b.Name)
+	}
+	vals.Set(namespace+".barweight."+key, b.Weight.String())
+	vals.Set(namespace+".barunit."+key, b.Unit.String())
+	if b.Sleeve > 0 {
+		vals.Set(namespace+".barsleeve."+key, fmt.Sprintf("%v", b.Sleeve))
+	}
+	if b.Fixed {
+		vals.Set(namespace+".barfixed."+key, "true")
+	}
+}
+
+// barFromValues is the inverse of barValues, it returns false when the movement of the
+// key has no Bar.
+func barFromValues(v url.Values, key string) (gear.Bar, bool, error) {
+	w := v.Get(namespace + ".barweight." + key)
+	if w == "" {
+		name := v.Get(namespace + ".bar." + key)
+		if name == "" {
+			return gear.Bar{}, false, nil
+		}
+		b, err := gear.BarFromName(name)
+		return b, true, err
+	}
+	b := gear.Bar{Name: v.Get(namespace + ".barname." + key), Fixed: isChecked(namespace+".barfixed."+key, v)}
+	var err error
+	if b.Weight, err = gear.ParseWeight(w); err != nil {
+		return b, true, fmt.Errorf("unable to convert %v to weight", w)
+	}
+	if b.Unit, err = gear.UnitFromString(v.Get(namespace + ".barunit." + key)); err != nil {
+		return b, true, err
+	}
+	if sleeve := v.Get(namespace + ".barsleeve." + key); sleeve != "" {
+		if b.Sleeve, err = strconv.ParseFloat(sleeve, 64); err != nil {
+			return b, true, err
+		}
+	}
+	return b, true, b.Valid()
+}
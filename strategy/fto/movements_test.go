@@ -0,0 +1,185 @@
+package fto
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestMovementKey(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name, expected string
+	}{
+		{"squat", "squat"},
+		{"Front Squat", "front-squat"},
+		{"  trap bar -- deadlift ", "trap-bar-deadlift"},
+		{"5/3/1 press", "5-3-1-press"},
+		{"", "movement"},
+		{"!!", "movement"},
+	}
+	for _, test := range tt {
+		if k := movementKey(test.name); k != test.expected {
+			t.Error("unexpected key:", test.name, k, test.expected)
+		}
+	}
+	keys := movementKeys([]Movement{{Name: "squat"}, {Name: "Squat"}, {Name: "squat"}, {Name: "press"}})
+	if strings.Join(keys, " ") != "squat squat-2 squat-3 press" {
+		t.Error("unexpected keys:", keys)
+	}
+}
+
+func TestFromValuesMovements(t *testing.T) {
+	t.Parallel()
+	trap := gear.TrapBar
+	kettlebells := gear.Plates{Unit: gear.LBS}
+	kettlebells.Add(35 * gear.Precision)
+	kettlebells.Add(53 * gear.Precision)
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "trap bar deadlift", TrainingMax: 405 * gear.Precision, Unit: gear.LBS, Bar: &trap},
+			{Name: "front squat", TrainingMax: 100 * gear.Precision, Unit: gear.KG},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 150 * gear.Precision, Unit: gear.LBS},
+			{Name: "swing", TrainingMax: 24 * gear.Precision, Unit: gear.KG, Implements: &kettlebells},
+		},
+		Gear: gear.Default(gear.LBS),
+		Type: FSL,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k := strings.Join(vals["fto.movement"], " "); k != "trap-bar-deadlift front-squat bench-press bench-press-2 swing" {
+		t.Error("unexpected movements:", k)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Movements) != len(s.Movements) {
+		t.Fatal("unexpected number of movements:", len(o.Movements))
+	}
+	for i, m := range o.Movements {
+		e := s.Movements[i]
+		if m.Name != e.Name || m.TrainingMax != e.TrainingMax || m.Unit != e.Unit {
+			t.Error("unexpected movement:", i, m.Name, m.TrainingMax, m.Unit)
+		}
+	}
+	if o.Movements[0].Bar == nil || !o.Movements[0].Bar.Equals(trap) {
+		t.Error("unexpected bar:", o.Movements[0].Bar)
+	}
+	if i := o.Movements[4].Implements; i == nil || i.Unit != gear.KG || len(i.Weights) != 2 {
+		t.Error("unexpected implements:", i)
+	}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j Strategy
+	if err := json.Unmarshal(b, &j); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(j.Movements, o.Movements) {
+		t.Error("unexpected json round trip:", j.Movements)
+	}
+
+	// the order of the values doesn't matter, only the order of fto.movement.
+	reordered, _ := s.Values()
+	reordered["fto.movement"] = []string{"swing", "front-squat"}
+	if o, err := FromValues(reordered); err != nil || len(o.Movements) != 2 || o.Movements[0].Name != "swing" {
+		t.Error("unexpected movements:", o.Movements, err)
+	}
+
+	// empty names are left out, such as the unused rows of the form.
+	empty, _ := s.Values()
+	empty.Add("fto.movement", "custom-1")
+	empty.Set("fto.name.custom-1", "")
+	empty.Set("fto.tm.custom-1", "")
+	if o, err := FromValues(empty); err != nil || len(o.Movements) != 5 {
+		t.Error("unexpected movements:", len(o.Movements), err)
+	}
+
+	// bars outside of the catalog keep their weight, unit and sleeve, with or without a name.
+	bars := Strategy{
+		Movements: []Movement{
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS, Bar: &gear.Bar{Name: "cambered", Weight: 70 * gear.Precision, Unit: gear.LBS, Sleeve: 380}, Pair: "front squat"},
+			{Name: "front squat", TrainingMax: 100 * gear.Precision, Unit: gear.KG, Bar: &gear.Bar{Weight: 25 * gear.Precision, Unit: gear.KG}},
+			{Name: "curl", TrainingMax: 30 * gear.Precision, Unit: gear.KG, Bar: &gear.Bar{Name: "mens-kg", Weight: 10 * gear.Precision, Unit: gear.KG, Fixed: true}},
+		},
+		Gear: gear.Default(gear.LBS),
+		Type: BBB,
+	}
+	bv, err := bars.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := FromValues(bv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range cb.Movements {
+		if e := bars.Movements[i]; m.Bar == nil || !m.Bar.Equals(*e.Bar) || m.Pair != e.Pair {
+			t.Error("unexpected movement:", i, m.Bar, m.Pair)
+		}
+	}
+
+	tt := []struct {
+		key, value string
+	}{
+		{"fto.movement", "swing"},
+		{"fto.barweight.swing", "heavy"},
+		{"fto.unit.swing", "GRAMS"},
+		{"fto.tm.swing", "heavy"},
+	}
+	for _, test := range tt {
+		v, _ := s.Values()
+		if test.key == "fto.movement" {
+			v.Add(test.key, test.value)
+		} else {
+			v.Set(test.key, test.value)
+		}
+		if _, err := FromValues(v); err == nil {
+			t.Error("expected an error:", test.key, test.value)
+		}
+	}
+}
+
+func TestFromValuesLegacyMovements(t *testing.T) {
+	t.Parallel()
+	v, _ := gear.ToValues(gear.Default(gear.KG))
+	v.Set("fto.strategy", FSL.String())
+	v.Set("fto.0", "180")
+	v.Set("fto.1", "100")
+	v.Set("fto.2", "60")
+	v.Set("fto.3", "140")
+	v.Set("fto.bar.0", gear.TrapBar.Name)
+	v.Set("fto.increase.3", NoIncrease.String())
+	s, err := FromValues(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Movements) != 4 {
+		t.Fatal("unexpected number of movements:", len(s.Movements))
+	}
+	for i, m := range s.Movements {
+		if m.Name != DefaultMovements[i] || m.Unit != gear.KG {
+			t.Error("unexpected movement:", i, m.Name, m.Unit)
+		}
+	}
+	if s.Movements[3].TrainingMax != 140*gear.Precision || s.Movements[0].Bar == nil || s.Movements[3].Increase == nil {
+		t.Error("unexpected movements:", s.Movements)
+	}
+	v.Del("fto.2")
+	if _, err := FromValues(v); err == nil || err.Error() != "movement fto.2 not found" {
+		t.Error("unexpected error:", err)
+	}
+	if _, err := FromValues(url.Values{}); err == nil {
+		t.Error("expected an error without values")
+	}
+}
@@ -3,9 +3,11 @@ package fto
 import (
 	"bytes"
 	_ "embed" // used for embeding templates
+	"fmt"
 	"html/template"
 
 	"github.com/liftplan/liftplan"
+	"github.com/liftplan/liftplan/gear"
 )
 
 var (
@@ -13,6 +15,9 @@ var (
 	formTemplate string
 )
 
+// customMovements is the number of empty movements in the form, beside the DefaultMovements.
+const customMovements = 2
+
 type input struct {
 	Template *template.Template
 	Options  options
@@ -22,6 +27,17 @@ type options struct {
 	Movements   []choice
 	Selectables []choice
 	Strategies  []choice
+	MainSets    []choice
+	Bars        []choice
+	Increases   []choice
+	Units       []choice
+	Formulas    []choice
+	TMPercents  []choice
+	Cycles      uint
+	MaxCycles   uint
+	Deloads     []choice
+	DeloadEvery []choice
+	BBBPercents []float64
 }
 
 type choice struct {
@@ -39,11 +55,18 @@ func FormFields() liftplan.FormFields {
 		{Name: "Recommended Plates", Value: "recplates", Checked: false},
 	}
 
-	mo := []choice{
-		{Name: "deadlift", Value: "0"},
-		{Name: "bench press", Value: "1"},
-		{Name: "overhead press", Value: "2"},
-		{Name: "back squat", Value: "3"},
+	var mo []choice
+	for _, m := range DefaultMovements {
+		mo = append(mo, choice{Name: m, Value: movementKey(m)})
+	}
+	for i := 1; i <= customMovements; i++ {
+		mo = append(mo, choice{Value: fmt.Sprintf("custom-%v", i)})
+	}
+
+	units := []choice{
+		{Name: "gear unit", Value: "", Checked: true},
+		{Name: gear.KG.String(), Value: gear.KG.String()},
+		{Name: gear.LBS.String(), Value: gear.LBS.String()},
 	}
 
 	strats := []choice{
@@ -57,9 +80,72 @@ func FormFields() liftplan.FormFields {
 			Value:   FSL.String(),
 			Checked: false,
 		},
+		{
+			Name:    BBB.String(),
+			Value:   BBB.String(),
+			Checked: false,
+		},
+		{
+			Name:    SSL.String(),
+			Value:   SSL.String(),
+			Checked: false,
+		},
+		{
+			Name:    TOPSETS.String(),
+			Value:   TOPSETS.String(),
+			Checked: false,
+		},
+	}
+
+	mains := []choice{
+		{Name: PRSets.String(), Value: PRSets.String(), Checked: true},
+		{Name: FivesPro.String(), Value: FivesPro.String()},
+		{Name: MinimumReps.String(), Value: MinimumReps.String()},
+	}
+
+	bars := []choice{{Name: "gear bar", Value: "", Checked: true}}
+	for _, b := range gear.Bars {
+		bars = append(bars, choice{Name: fmt.Sprintf("%v (%v %v)", b.Name, b.Weight, b.Unit), Value: b.Name})
+	}
+
+	increases := []choice{
+		{Name: fmt.Sprintf("default (%v%%)", TMIncreaseFactor*100), Value: "", Checked: true},
+		{Name: "percent", Value: PercentIncrease.String()},
+		{Name: "fixed weight", Value: FixedIncrease.String()},
+		{Name: "none", Value: NoIncrease.String()},
+	}
+
+	deloads := []choice{
+		{Name: "5x40%, 5x50%, 5x60%", Value: Deload1.String(), Checked: true},
+		{Name: "5x50%, 5x60%, 5x70%", Value: Deload2.String()},
+		{Name: "3x65%, 3x75%, 3x85%", Value: Deload3.String()},
+		{Name: "10x40%, 8x50%, 6x60%", Value: Deload4.String()},
+		{Name: "10x50%, 8x60%, 6x70%", Value: Deload5.String()},
+	}
+
+	every := []choice{
+		{Name: "every cycle", Value: "1"},
+		{Name: "every 2 cycles", Value: "2", Checked: true},
+		{Name: "every 3 cycles", Value: "3"},
+		{Name: "every 4 cycles", Value: "4"},
+		{Name: "never", Value: "none"},
+	}
+
+	formulas := []choice{
+		{Name: "Epley", Value: Epley.String(), Checked: true},
+		{Name: "Brzycki", Value: Brzycki.String()},
+		{Name: "Wathan", Value: Wathan.String()},
+	}
+
+	tmpercents := []choice{
+		{Name: "90% of 1RM", Value: "90", Checked: true},
+		{Name: "85% of 1RM", Value: "85"},
 	}
 
-	o := options{Selectables: s, Movements: mo, Strategies: strats}
+	o := options{Selectables: s, Movements: mo, Strategies: strats, MainSets: mains, Bars: bars, Increases: increases,
+		Formulas: formulas, TMPercents: tmpercents, Units: units,
+		Cycles: DefaultCycles, MaxCycles: MaxCycles, Deloads: deloads, DeloadEvery: every,
+		BBBPercents: DefaultBBBPercents}
 	t, _ := template.New("fto").Parse(formTemplate)
 	return input{Template: t, Options: o}
 }
@@ -1,13 +1,22 @@
 package fto
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestFormFields(t *testing.T) {
 	t.Parallel()
 	f := FormFields()
-	if _, err := f.Render(); err != nil {
+	h, err := f.Render()
+	if err != nil {
 		t.Error(err)
 	}
+	for _, c := range []string{`name="fto.tm.overhead-press"`, `value="squat"`, `name="fto.name.custom-1"`} {
+		if !strings.Contains(string(h), c) {
+			t.Error("expected form to contain:", c)
+		}
+	}
 	if f.Name() != "Beyond 5/3/1" {
 		t.Error("unexpected Name")
 	}
@@ -0,0 +1,183 @@
+package fto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+const (
+	// TMFactor is the percent of an estimated 1 rep max used as the training max.
+	TMFactor float64 = 0.9
+	// ResetFactor is the percent a training max is lowered by when a lift is reset.
+	ResetFactor float64 = 0.1
+)
+
+var (
+	// ErrInvalidFormula represents an invalid Formula
+	ErrInvalidFormula = errors.New("invalid Formula")
+	// ErrInvalidLoggedReps is returned for reps a Formula can't estimate a 1 rep max from.
+	ErrInvalidLoggedReps = errors.New("invalid logged reps")
+	// ErrNoLoggedSets is returned when a Progression has no logged sets to recalculate from.
+	ErrNoLoggedSets = errors.New("no logged sets")
+)
+
+// Formula is an ENUM type for the formulas that estimate a 1 rep max from a set of reps.
+type Formula uint
+
+const (
+	// Epley estimates weight * (1 + reps / 30).
+	Epley Formula = iota
+	// Brzycki estimates weight * 36 / (37 - reps), for less than 37 reps.
+	Brzycki
+	// Wathan estimates 100 * weight / (48.8 + 53.8 * e^(-0.075 * reps)).
+	Wathan
+)
+
+var stringToFormula = map[string]Formula{
+	"epley":   Epley,
+	"brzycki": Brzycki,
+	"wathan":  Wathan,
+}
+
+// FormulaFromString takes a string and returns a Formula and an error
+func FormulaFromString(s string) (Formula, error) {
+	formula, ok := stringToFormula[s]
+	if !ok {
+		return 0, ErrInvalidFormula
+	}
+	return formula, nil
+}
+
+// String is the string representation of a formula
+func (f Formula) String() string {
+	n := []string{"epley", "brzycki", "wathan"}
+	if int(f) < len(n) {
+		return n[f]
+	}
+	return ""
+}
+
+// MarshalJSON is the json marshaller for Formula
+func (f Formula) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%v"`, f.String())), nil
+}
+
+// UnmarshalJSON is the json unmarshaller for Formula
+func (f *Formula) UnmarshalJSON(b []byte) error {
+	var fs string
+	if err := json.Unmarshal(b, &fs); err != nil {
+		return err
+	}
+	formula, err := FormulaFromString(fs)
+	*f = formula
+	return err
+}
+
+// E1RM takes the weight and reps of a set and returns the estimated 1 rep max. A single
+// rep is its own 1 rep max, and it returns ErrInvalidLoggedReps for zero reps.
+func (f Formula) E1RM(weight gear.Weight, reps uint) (gear.Weight, error) {
+	if reps == 0 {
+		return 0, ErrInvalidLoggedReps
+	}
+	if reps == 1 {
+		return weight, nil
+	}
+	r := float64(reps)
+	switch f {
+	case Epley:
+		return weight.Mul(1 + r/30), nil
+	case Brzycki:
+		if reps >= 37 {
+			return 0, ErrInvalidLoggedReps
+		}
+		return weight.Mul(36 / (37 - r)), nil
+	case Wathan:
+		return weight.Mul(100 / (48.8 + 53.8*math.Exp(-0.075*r))), nil
+	default:
+		return 0, ErrInvalidFormula
+	}
+}
+
+// NextTrainingMaxes takes a finished Progression with the reps of its Working sets logged
+// and returns every Movement with the training max for the next cycle, the TMFactor of
+// the best estimated 1 rep max from the last Week the Movement was logged in. A Movement
+// with any logged set below its prescribed reps in that Week keeps its training max, or
+// is lowered by the ResetFactor when reset is true. Movements without logged sets are
+// left out, and it returns ErrNoLoggedSets when there are none.
+func (p Progression) NextTrainingMaxes(f Formula, reset bool) ([]Movement, error) {
+	if f.String() == "" {
+		return nil, ErrInvalidFormula
+	}
+	var names []string
+	last := map[string]int{}
+	for i, w := range p {
+		for _, sess := range w.Sessions {
+			for _, set := range sess {
+				if set.Type != Working || set.Logged == nil {
+					continue
+				}
+				if _, ok := last[set.Movement.Name]; !ok {
+					names = append(names, set.Movement.Name)
+				}
+				last[set.Movement.Name] = i
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, ErrNoLoggedSets
+	}
+	movements := make([]Movement, len(names))
+	for i, name := range names {
+		m, err := p[last[name]].nextTrainingMax(name, f, reset)
+		if err != nil {
+			return nil, err
+		}
+		movements[i] = m
+	}
+	return movements, nil
+}
+
+// nextTrainingMax returns the Movement of the name with the training max for the next cycle
+// from the logged Working sets of the Week. Each set is estimated from its rounded Weight,
+// and the training max is held or reset from the one before the TMPercent of the Week.
+func (w Week) nextTrainingMax(name string, f Formula, reset bool) (Movement, error) {
+	var m Movement
+	var best gear.Weight
+	var stalled bool
+	for _, sess := range w.Sessions {
+		for _, set := range sess {
+			if set.Type != Working || set.Logged == nil || set.Movement.Name != name {
+				continue
+			}
+			m = set.Movement
+			if *set.Logged < set.Reps {
+				stalled = true
+				continue
+			}
+			weight, err := gear.ConvertFromTo(set.Weight, w.Unit, m.Unit)
+			if err != nil {
+				return m, err
+			}
+			e, err := f.E1RM(weight, *set.Logged)
+			if err != nil {
+				return m, err
+			}
+			best = max(best, e)
+		}
+	}
+	if w.TMPercent != 0 && w.TMPercent != 100 {
+		m.TrainingMax = gear.Weight(math.Round(float64(m.TrainingMax) * 100 / w.TMPercent))
+	}
+	switch {
+	case stalled && reset:
+		m.TrainingMax = m.TrainingMax.Mul(1 - ResetFactor)
+	case !stalled:
+		m.TrainingMax = min(best.Mul(TMFactor), MaxTrainingMax)
+	}
+	m.Calculated = false
+	return m, nil
+}
@@ -0,0 +1,186 @@
+package fto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/liftplan/liftplan/gear"
+)
+
+func TestFormula(t *testing.T) {
+	t.Parallel()
+	for _, f := range []Formula{Epley, Brzycki, Wathan} {
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var o Formula
+		if err := json.Unmarshal(b, &o); err != nil || o != f {
+			t.Error("unexpected round trip:", f, o, err)
+		}
+	}
+	if _, err := FormulaFromString("lombardi"); err != ErrInvalidFormula {
+		t.Error("expected an invalid formula:", err)
+	}
+	if s := Formula(10).String(); s != "" {
+		t.Error(s, "!= ''")
+	}
+}
+
+func TestFormulaE1RM(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		formula  Formula
+		weight   gear.Weight
+		reps     uint
+		expected gear.Weight
+		err      error
+	}{
+		{Epley, 200 * gear.Precision, 5, 233333, nil},
+		{Brzycki, 200 * gear.Precision, 5, 225 * gear.Precision, nil},
+		{Wathan, 200 * gear.Precision, 5, 233165, nil},
+		{Wathan, 200 * gear.Precision, 1, 200 * gear.Precision, nil},
+		{Epley, 200 * gear.Precision, 0, 0, ErrInvalidLoggedReps},
+		{Brzycki, 200 * gear.Precision, 37, 0, ErrInvalidLoggedReps},
+		{Formula(10), 200 * gear.Precision, 5, 0, ErrInvalidFormula},
+	}
+	for i, test := range tt {
+		e, err := test.formula.E1RM(test.weight, test.reps)
+		if err != test.err {
+			t.Error("unexpected error:", i, err)
+			continue
+		}
+		if d := e - test.expected; d < -1 || d > 1 {
+			t.Error("unexpected e1rm:", i, e, test.expected)
+		}
+	}
+}
+
+func TestProgressionNextTrainingMaxes(t *testing.T) {
+	t.Parallel()
+	reps := func(n uint) *uint { return &n }
+	squat := Movement{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS, Calculated: true}
+	bench := Movement{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS}
+	deadlift := Movement{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS}
+	p := Progression{
+		{Unit: gear.LBS, Sessions: []Session{
+			{{Movement: squat, Percent: 85, Reps: 5, AMRAP: true, Type: Working, Weight: 255 * gear.Precision, Logged: reps(20)}},
+		}},
+		{Unit: gear.LBS, Sessions: []Session{
+			{
+				{Movement: squat, Percent: 75, Reps: 5, Type: Working, Weight: 225 * gear.Precision, Logged: reps(5)},
+				{Movement: squat, Percent: 85, Reps: 5, AMRAP: true, Type: Working, Weight: 255 * gear.Precision, Logged: reps(8)},
+				{Movement: squat, Percent: 50, Reps: 10, Type: Auxiliary, Weight: 150 * gear.Precision, Logged: reps(3)},
+			},
+			{
+				{Movement: bench, Percent: 75, Reps: 5, Type: Working, Weight: 150 * gear.Precision, Logged: reps(5)},
+				{Movement: bench, Percent: 85, Reps: 5, AMRAP: true, Type: Working, Weight: 170 * gear.Precision, Logged: reps(3)},
+			},
+			{{Movement: deadlift, Percent: 85, Reps: 5, AMRAP: true, Type: Working, Weight: 340 * gear.Precision}},
+		}},
+	}
+
+	tt := []struct {
+		reset bool
+		squat gear.Weight
+		bench gear.Weight
+	}{
+		{false, 290700, 200 * gear.Precision},
+		{true, 290700, 180 * gear.Precision},
+	}
+	for _, test := range tt {
+		m, err := p.NextTrainingMaxes(Epley, test.reset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(m) != 2 || m[0].Name != "squat" || m[1].Name != "bench press" {
+			t.Fatal("unexpected movements:", m)
+		}
+		if d := m[0].TrainingMax - test.squat; d < -1 || d > 1 || m[0].Calculated {
+			t.Error("unexpected squat:", test.reset, m[0].TrainingMax, m[0].Calculated)
+		}
+		if m[1].TrainingMax != test.bench {
+			t.Error("unexpected bench press:", test.reset, m[1].TrainingMax)
+		}
+	}
+
+	if _, err := (Progression{}).NextTrainingMaxes(Epley, false); err != ErrNoLoggedSets {
+		t.Error("expected no logged sets:", err)
+	}
+	if _, err := p.NextTrainingMaxes(Formula(10), false); err != ErrInvalidFormula {
+		t.Error("expected an invalid formula:", err)
+	}
+}
+
+func TestProgressionNextTrainingMaxesBlock(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS}},
+		Gear:      gear.Default(gear.KG),
+		Blocks:    []Block{{Cycles: 1, Type: FSL, TMPercent: 85}},
+	}
+	schedule, err := s.schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newProgression(s.Movements, s.Deload, schedule)
+	if err := p.calculate(false, bbb{}, s.Gear); err != nil {
+		t.Fatal(err)
+	}
+	log := func(reps func(Set) uint) Progression {
+		var o Progression
+		b, _ := json.Marshal(p)
+		json.Unmarshal(b, &o)
+		for _, w := range o {
+			for _, sess := range w.Sessions {
+				for i, set := range sess {
+					if set.Type == Working {
+						r := reps(set)
+						sess[i].Logged = &r
+					}
+				}
+			}
+		}
+		return o
+	}
+
+	// a stalled lift holds, or resets, the training max of the movement and not the
+	// 85 percent of it used by the block.
+	stalled := log(func(Set) uint { return 1 })
+	for _, test := range []struct {
+		reset    bool
+		expected gear.Weight
+	}{
+		{false, 300 * gear.Precision},
+		{true, 270 * gear.Precision},
+	} {
+		m, err := stalled.NextTrainingMaxes(Epley, test.reset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m[0].TrainingMax != test.expected {
+			t.Error("unexpected training max:", test.reset, m[0].TrainingMax, test.expected)
+		}
+	}
+
+	// the 1 rep max is estimated from the weight lifted, rounded to the plates in kg.
+	logged := log(func(s Set) uint { return s.Reps })
+	m, err := logged.NextTrainingMaxes(Epley, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected gear.Weight
+	for _, sess := range logged[len(logged)-1].Sessions {
+		for _, set := range sess {
+			if set.Type != Working {
+				continue
+			}
+			w, _ := gear.ConvertFromTo(set.Weight, gear.KG, gear.LBS)
+			e, _ := Epley.E1RM(w, set.Reps)
+			expected = max(expected, e)
+		}
+	}
+	if expected = expected.Mul(TMFactor); m[0].TrainingMax != expected {
+		t.Error("unexpected training max:", m[0].TrainingMax, expected)
+	}
+}
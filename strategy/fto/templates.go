@@ -165,3 +165,32 @@ var deloadTemplate = map[DeloadType]Session{
 		},
 	},
 }
+
+// tmTestTemplate works up to the training max for 5 reps, if all 5 reps can't be
+// done with good form the training max is too heavy.
+var tmTestTemplate = Session{
+	Set{
+		Percent: 70,
+		Reps:    5,
+		AMRAP:   false,
+		Type:    Working,
+	},
+	Set{
+		Percent: 80,
+		Reps:    5,
+		AMRAP:   false,
+		Type:    Working,
+	},
+	Set{
+		Percent: 90,
+		Reps:    5,
+		AMRAP:   false,
+		Type:    Working,
+	},
+	Set{
+		Percent: 100,
+		Reps:    5,
+		AMRAP:   false,
+		Type:    Working,
+	},
+}
@@ -30,38 +30,119 @@ func FromValues(v url.Values) (s Strategy, err error) {
 		return s, err
 	}
 
-	movements := []string{"deadlift", "bench press", "overhead press", "squat"}
-	m := make([]Movement, len(movements))
+	m, err := movementsFromValues(v, g)
+	if err != nil {
+		return s, err
+	}
+
+	var profile string
+	if p, ok, _ := gear.ProfileFromValues(v); ok {
+		profile = p.Name
+	}
 
-	for i := 0; i < len(movements); i++ {
-		k := fmt.Sprintf(namespace+".%v", i)
-		x, ok := v[k]
-		if !ok {
-			return s, fmt.Errorf("movement %v not found", k)
+	var cycles uint64
+	if c, ok := v[namespace+".cycles"]; ok && c[0] != "" {
+		cycles, err = strconv.ParseUint(c[0], 10, 32)
+		if err != nil {
+			return s, ErrInvalidCycles
+		}
+	}
+	var deload DeloadType
+	if d, ok := v[namespace+".deload"]; ok && d[0] != "" {
+		deload, err = DeloadTypeFromString(d[0])
+		if err != nil {
+			return s, err
 		}
-		tm, err := strconv.ParseFloat(x[0], 64)
+	}
+	var every uint64
+	var sevenths []SeventhWeek
+	if e, ok := v[namespace+".deloadevery"]; ok && e[0] != "" {
+		if e[0] == "none" {
+			sevenths = []SeventhWeek{}
+		} else if every, err = strconv.ParseUint(e[0], 10, 32); err != nil || every == 0 {
+			return s, ErrInvalidDeloadEvery
+		}
+	}
+	if sw, ok := v[namespace+".seventh"]; ok && sw[0] != "" {
+		sevenths, err = ParseSeventhWeeks(sw[0])
 		if err != nil {
-			return s, fmt.Errorf("unable to convert %v to float", x[0])
+			return s, err
 		}
+	}
 
-		m[i] = Movement{
-			Name:        movements[i],
-			TrainingMax: tm,
-			Unit:        g.Unit,
+	var main MainSetMode
+	if ms, ok := v[namespace+".main"]; ok && ms[0] != "" {
+		main, err = MainSetModeFromString(ms[0])
+		if err != nil {
+			return s, err
+		}
+	}
+
+	var percents []float64
+	for _, p := range v[namespace+".bbb"] {
+		if p == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return s, fmt.Errorf("unable to convert %v to percent", p)
 		}
+		percents = append(percents, f)
+	}
+
+	blocks, err := blocksFromValues(v)
+	if err != nil {
+		return s, err
 	}
 
 	s = Strategy{
 		Movements:       m,
 		Gear:            g,
+		Profile:         profile,
 		Type:            t,
+		MainSets:        main,
+		Deload:          deload,
+		Cycles:          uint(cycles),
+		DeloadEvery:     uint(every),
+		SeventhWeeks:    sevenths,
 		Warmup:          isChecked(namespace+".warmup", v),
 		JokerSets:       isChecked(namespace+".jokersets", v),
 		RecommendPlates: isChecked(namespace+".recplates", v),
+		BBBPercents:     percents,
+		BBBPaired:       isChecked(namespace+".bbbpaired", v),
+		Blocks:          blocks,
+	}
+	if _, err := s.schedule(); err != nil {
+		return s, err
+	}
+	if _, err := s.bbb(); err != nil {
+		return s, err
 	}
 	return s, nil
 }
 
+// increaseFromValues takes the type and amount of an Increase and returns the Increase,
+// the amount is a percent or a weight in the units of the Movement.
+func increaseFromValues(t, by string) (inc Increase, err error) {
+	inc.Type, err = IncreaseTypeFromString(t)
+	if err != nil {
+		return inc, err
+	}
+	switch inc.Type {
+	case PercentIncrease:
+		inc.Percent, err = strconv.ParseFloat(by, 64)
+		if err != nil {
+			return inc, fmt.Errorf("unable to convert %v to percent", by)
+		}
+	case FixedIncrease:
+		inc.Weight, err = gear.ParseWeight(by)
+		if err != nil {
+			return inc, fmt.Errorf("unable to convert %v to weight", by)
+		}
+	}
+	return inc, inc.Valid()
+}
+
 func isChecked(key string, vals url.Values) bool {
 	v, ok := vals[key]
 	if !ok {
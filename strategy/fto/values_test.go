@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"reflect"
 	"testing"
 
 	"github.com/liftplan/liftplan/gear"
@@ -40,22 +41,22 @@ func TestFromValues(t *testing.T) {
 
 	m1 := Movement{
 		Name:        "deadlift",
-		TrainingMax: 175,
+		TrainingMax: 175 * gear.Precision,
 		Unit:        gear.LBS,
 	}
 	m2 := Movement{
 		Name:        "bench press",
-		TrainingMax: 400,
+		TrainingMax: 400 * gear.Precision,
 		Unit:        gear.LBS,
 	}
 	m3 := Movement{
 		Name:        "overhead press",
-		TrainingMax: 175,
+		TrainingMax: 175 * gear.Precision,
 		Unit:        gear.LBS,
 	}
 	m4 := Movement{
 		Name:        "squat",
-		TrainingMax: 400,
+		TrainingMax: 400 * gear.Precision,
 		Unit:        gear.LBS,
 	}
 	s1 := Strategy{
@@ -72,10 +73,14 @@ func TestFromValues(t *testing.T) {
 	badStrat, _ := s1.Values()
 	badStrat["fto.strategy"] = []string{"blah"}
 	missingMovement, _ := s1.Values()
-	missingMovement.Del("fto.1")
+	missingMovement.Del("fto.tm.bench-press")
 
 	malformedTM, _ := s1.Values()
-	malformedTM.Set("fto.0", "woot")
+	malformedTM.Set("fto.tm.deadlift", "woot")
+	badBar, _ := s1.Values()
+	badBar.Set("fto.bar.deadlift", "yoke")
+	badImplement, _ := s1.Values()
+	badImplement.Add("fto.implement.deadlift", "woot")
 
 	tt := []struct {
 		input    url.Values
@@ -86,8 +91,10 @@ func TestFromValues(t *testing.T) {
 		{goodVals, s1, nil},
 		{missingStrat, s1, errors.New("missing strategy in query")},
 		{badStrat, s1, ErrInvalidStrategyType},
-		{missingMovement, s1, fmt.Errorf("movement %v not found", "fto.1")},
-		{malformedTM, s1, fmt.Errorf("unable to convert %v to float", "woot")},
+		{missingMovement, s1, fmt.Errorf("movement %v not found", "fto.tm.bench-press")},
+		{malformedTM, s1, fmt.Errorf("unable to convert %v to weight", "woot")},
+		{badBar, s1, gear.ErrBarNotFound},
+		{badImplement, s1, fmt.Errorf("unable to convert %v to weight", "woot")},
 	}
 
 	for _, test := range tt {
@@ -99,3 +106,375 @@ func TestFromValues(t *testing.T) {
 		}
 	}
 }
+
+func TestFromValuesBar(t *testing.T) {
+	t.Parallel()
+	trap := gear.TrapBar
+	dumbbells := gear.DefaultDumbbellsLBS
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS, Bar: &trap},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS, Implements: &dumbbells},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear: gear.Default(gear.LBS),
+		Type: FSL,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range o.Movements {
+		if (m.Bar == nil) != (s.Movements[i].Bar == nil) {
+			t.Error("unexpected bar:", i, m.Bar)
+		} else if m.Bar != nil && !m.Bar.Equals(*s.Movements[i].Bar) {
+			t.Error("unexpected bar:", i, m.Bar, s.Movements[i].Bar)
+		}
+		if (m.Implements == nil) != (s.Movements[i].Implements == nil) {
+			t.Error("unexpected implements:", i, m.Implements)
+		} else if m.Implements != nil && !m.Implements.Equals(*s.Movements[i].Implements) {
+			t.Error("unexpected implements:", i, m.Implements, s.Movements[i].Implements)
+		}
+	}
+}
+
+func TestFromValuesIncrease(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS,
+				Increase: &Increase{Type: FixedIncrease, Weight: 10 * gear.Precision}},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS,
+				Increase: &Increase{Type: PercentIncrease, Percent: 2.5}},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS,
+				Increase: &Increase{Type: NoIncrease}},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear: gear.Default(gear.LBS),
+		Type: FSL,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range o.Movements {
+		e := s.Movements[i].Increase
+		if (m.Increase == nil) != (e == nil) {
+			t.Error("unexpected increase:", i, m.Increase)
+		} else if m.Increase != nil && *m.Increase != *e {
+			t.Error("unexpected increase:", i, *m.Increase, *e)
+		}
+	}
+
+	tt := []struct {
+		increase, by string
+		err          bool
+	}{
+		{"", "", false},
+		{"weekly", "5", true},
+		{"percent", "lots", true},
+		{"percent", "", true},
+		{"percent", "-2", true},
+		{"fixed", "heavy", true},
+		{"fixed", "0", true},
+		{"none", "", false},
+	}
+	for _, test := range tt {
+		vals.Set("fto.increase.squat", test.increase)
+		vals.Set("fto.increaseby.squat", test.by)
+		if _, err := FromValues(vals); (err != nil) != test.err {
+			t.Error("unexpected error:", test.increase, test.by, err)
+		}
+	}
+}
+
+func TestFromValuesCycles(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:         gear.Default(gear.LBS),
+		Type:         FSL,
+		Cycles:       6,
+		SeventhWeeks: []SeventhWeek{{2, TMTestWeek}, {4, DeloadWeek}, {6, DeloadWeek}},
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, sw := vals.Get("fto.cycles"), vals.Get("fto.seventh"); c != "6" || sw != "2:tmtest,4:deload,6:deload" {
+		t.Error("unexpected values:", c, sw)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Cycles != s.Cycles || formatSeventhWeeks(o.SeventhWeeks) != formatSeventhWeeks(s.SeventhWeeks) {
+		t.Error("unexpected strategy:", o.Cycles, o.SeventhWeeks)
+	}
+
+	s.SeventhWeeks = []SeventhWeek{}
+	vals, _ = s.Values()
+	if o, err := FromValues(vals); err != nil || o.SeventhWeeks == nil || len(o.SeventhWeeks) != 0 {
+		t.Error("unexpected seventh weeks:", o.SeventhWeeks, err)
+	}
+
+	tt := []struct {
+		cycles, seventh string
+		err             error
+	}{
+		{"", "", nil},
+		{"three", "", ErrInvalidCycles},
+		{"21", "", ErrInvalidCycles},
+		{"2", "3:deload", ErrInvalidSeventhWeek},
+		{"2", "1:rest", ErrInvalidSeventhWeekType},
+	}
+	for _, test := range tt {
+		vals.Set("fto.cycles", test.cycles)
+		vals.Set("fto.seventh", test.seventh)
+		if _, err := FromValues(vals); err != test.err {
+			t.Error("unexpected error:", test.cycles, test.seventh, err)
+		}
+	}
+}
+
+func TestFromValuesDeload(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:        gear.Default(gear.LBS),
+		Type:        FSL,
+		Deload:      Deload3,
+		Cycles:      4,
+		DeloadEvery: 1,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, e := vals.Get("fto.deload"), vals.Get("fto.deloadevery"); d != "deload3" || e != "1" {
+		t.Error("unexpected values:", d, e)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Deload != s.Deload || o.DeloadEvery != s.DeloadEvery || o.SeventhWeeks != nil {
+		t.Error("unexpected strategy:", o.Deload, o.DeloadEvery, o.SeventhWeeks)
+	}
+
+	vals.Set("fto.deloadevery", "none")
+	if o, err := FromValues(vals); err != nil || o.SeventhWeeks == nil || len(o.SeventhWeeks) != 0 {
+		t.Error("expected no deloads:", o.SeventhWeeks, err)
+	}
+	vals.Set("fto.seventh", "2:tmtest")
+	if o, err := FromValues(vals); err != nil || formatSeventhWeeks(o.SeventhWeeks) != "2:tmtest" {
+		t.Error("expected seventh weeks to replace the deload placement:", o.SeventhWeeks, err)
+	}
+
+	vals.Del("fto.deload")
+	vals.Del("fto.seventh")
+	vals.Del("fto.deloadevery")
+	if o, err := FromValues(vals); err != nil || o.Deload != Deload1 {
+		t.Error("expected the default deload:", o.Deload, err)
+	}
+
+	tt := []struct {
+		key, value string
+		err        error
+	}{
+		{"fto.deload", "deload9", ErrInvalidDeloadType},
+		{"fto.deloadevery", "0", ErrInvalidDeloadEvery},
+		{"fto.deloadevery", "often", ErrInvalidDeloadEvery},
+	}
+	for _, test := range tt {
+		v, _ := s.Values()
+		v.Set(test.key, test.value)
+		if _, err := FromValues(v); err != test.err {
+			t.Error("unexpected error:", test.key, test.value, err)
+		}
+	}
+}
+
+func TestFromValuesBBB(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:        gear.Default(gear.LBS),
+		Type:        BBB,
+		BBBPercents: []float64{40, 50, 62.5},
+		BBBPaired:   true,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Type != BBB || !o.BBBPaired || len(o.BBBPercents) != 3 || o.BBBPercents[2] != 62.5 {
+		t.Error("unexpected strategy:", o.Type, o.BBBPaired, o.BBBPercents)
+	}
+
+	tt := []struct {
+		percents []string
+		err      bool
+	}{
+		{[]string{"", "", ""}, false},
+		{[]string{"50"}, false},
+		{[]string{"fifty"}, true},
+		{[]string{"150"}, true},
+		{[]string{"50", "60", "70", "80"}, true},
+	}
+	for _, test := range tt {
+		vals["fto.bbb"] = test.percents
+		if _, err := FromValues(vals); (err != nil) != test.err {
+			t.Error("unexpected error:", test.percents, err)
+		}
+	}
+}
+
+func TestFromValuesMainSets(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:     gear.Default(gear.LBS),
+		Type:     SSL,
+		MainSets: FivesPro,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Type != SSL || o.MainSets != FivesPro {
+		t.Error("unexpected strategy:", o.Type, o.MainSets)
+	}
+
+	vals.Set("fto.main", "")
+	if o, err := FromValues(vals); err != nil || o.MainSets != PRSets {
+		t.Error("expected PR sets by default:", o.MainSets, err)
+	}
+	vals.Set("fto.main", "blah")
+	if _, err := FromValues(vals); err != ErrInvalidMainSetMode {
+		t.Error("expected an invalid mode:", err)
+	}
+}
+
+func TestFromValuesBlocks(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 400 * gear.Precision, Unit: gear.LBS},
+			{Name: "bench press", TrainingMax: 200 * gear.Precision, Unit: gear.LBS},
+			{Name: "overhead press", TrainingMax: 100 * gear.Precision, Unit: gear.LBS},
+			{Name: "squat", TrainingMax: 300 * gear.Precision, Unit: gear.LBS},
+		},
+		Gear:   gear.Default(gear.LBS),
+		Blocks: leaderAnchor(),
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(o.Blocks, s.Blocks) {
+		t.Error("unexpected blocks:", o.Blocks)
+	}
+
+	tt := []struct {
+		key, value string
+		err        error
+	}{
+		{"fto.block.cycles.1", "one", ErrInvalidBlock},
+		{"fto.block.cycles.1", "0", ErrInvalidBlock},
+		{"fto.block.main.0", "blah", ErrInvalidMainSetMode},
+		{"fto.block.strategy.0", "blah", ErrInvalidStrategyType},
+		{"fto.block.tm.0", "most", ErrInvalidTMPercent},
+		{"fto.block.tm.0", "110", ErrInvalidTMPercent},
+		{"fto.block.seventh.0", "rest", ErrInvalidSeventhWeekType},
+	}
+	for _, test := range tt {
+		v, _ := s.Values()
+		v.Set(test.key, test.value)
+		if _, err := FromValues(v); err != test.err {
+			t.Error("unexpected error:", test.key, test.value, err)
+		}
+	}
+}
+
+func TestFromValuesProfile(t *testing.T) {
+	t.Parallel()
+	s := Strategy{
+		Movements: []Movement{
+			{Name: "deadlift", TrainingMax: 200 * gear.Precision, Unit: gear.KG},
+			{Name: "bench press", TrainingMax: 100 * gear.Precision, Unit: gear.KG},
+			{Name: "overhead press", TrainingMax: 60 * gear.Precision, Unit: gear.KG},
+			{Name: "squat", TrainingMax: 160 * gear.Precision, Unit: gear.KG},
+		},
+		Profile: gear.CompetitionKGProfile.Name,
+		Type:    FSL,
+	}
+	vals, err := s.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := vals.Get("gear.profile"); p != "competition-kg" {
+		t.Error("unexpected profile in values:", p)
+	}
+	if p := vals.Get("gear.plate.kg"); p != "" {
+		t.Error("unexpected plates in values:", p)
+	}
+	o, err := FromValues(vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Profile != s.Profile || !o.Gear.Equals(gear.CompetitionKGProfile.Gear) {
+		t.Error("unexpected strategy:", o.Profile, o.Gear)
+	}
+	for i, m := range o.Movements {
+		if m.TrainingMax != s.Movements[i].TrainingMax {
+			t.Error("unexpected training max:", i, m.TrainingMax, s.Movements[i].TrainingMax)
+		}
+	}
+
+	s.Profile = "moon base"
+	if _, err := s.Values(); err != gear.ErrProfileNotFound {
+		t.Error("unexpected error:", err)
+	}
+}